@@ -1 +1,3 @@
 module projekt2
+
+go 1.21
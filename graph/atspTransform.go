@@ -0,0 +1,179 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// SymmetricTransformation opisuje przekształcenie n-wierzchołkowej instancji ATSP w 2n-wierzchołkową
+// instancję symetryczną (konstrukcja Jonkera-Volgenanta z wierzchołkami-duchami).
+// Wierzchołek v ma ducha n+v połączonego z nim krawędzią o wadze 0; łuk v -> u o wadze c staje się
+// krawędzią {n+v, u} o wadze c + GhostWeight. Krawędzie między dwoma wierzchołkami oryginalnymi
+// i między dwoma duchami nie istnieją. GhostWeight jest większe od kosztu każdej trasy ATSP,
+// więc optymalna trasa symetryczna zawiera wszystkie krawędzie {v, n+v} i kosztuje tyle co trasa ATSP plus Offset.
+type SymmetricTransformation struct {
+	VertexCount int // Liczba wierzchołków instancji ATSP (n)
+	GhostWeight int // Stała M dodawana do wag krawędzi między duchem a wierzchołkiem oryginalnym
+	Offset      int // n * M - różnica między kosztem trasy symetrycznej a kosztem odpowiadającej jej trasy ATSP
+}
+
+// TransformATSPToSTSP zapisuje w target symetryczny odpowiednik grafu g (2n wierzchołków).
+// Wagi niecałkowite są przenoszone, jeśli oba grafy je obsługują (FloatMatrixGraph).
+// Zwraca błąd, jeśli powiększone wagi nie mieszczą się w zakresie int lub w komórce grafu docelowego,
+// albo gdy któraś z nich jest równa noEdgeValue grafu docelowego.
+func TransformATSPToSTSP(g Graph, target MatrixGraph) (SymmetricTransformation, error) {
+	vertexCount := g.GetVertexCount()
+	if vertexCount == 0 {
+		return SymmetricTransformation{}, errors.New("graf nie ma wierzchołków")
+	}
+
+	_, floatSource := g.(FloatGraph)
+	_, floatTarget := target.(floatWeightSetter)
+	floatWeights := floatSource && floatTarget
+
+	// M = suma największych wag wychodzących + 1 przekracza koszt każdej trasy ATSP
+	ghostWeight := 1
+	for i := 0; i < vertexCount; i++ {
+		maxWeight := 0.0
+		for _, edge := range g.GetEdgesFromVertex(i) {
+			if edge.EndVertex != i {
+				maxWeight = math.Max(maxWeight, EdgeWeightFloat(g, i, edge.EndVertex))
+			}
+		}
+		rowMax := int(math.Ceil(maxWeight))
+		if addOverflows(ghostWeight, rowMax) {
+			return SymmetricTransformation{}, ErrPathWeightOverflow
+		}
+		ghostWeight += rowMax
+	}
+	if vertexCount > math.MaxInt/ghostWeight {
+		return SymmetricTransformation{}, ErrPathWeightOverflow
+	}
+	transformation := SymmetricTransformation{
+		VertexCount: vertexCount,
+		GhostWeight: ghostWeight,
+		Offset:      vertexCount * ghostWeight,
+	}
+
+	noEdgeValue := target.GetNoEdgeValue()
+	if noEdgeValue == 0 {
+		return SymmetricTransformation{}, errors.New("noEdgeValue grafu docelowego nie może być równe 0 - to waga krawędzi między wierzchołkiem a jego duchem")
+	}
+	edges := make([]Edge, 0, 2*vertexCount+2*g.GetEdgeCount())
+	for v := 0; v < vertexCount; v++ {
+		ghost := transformation.GhostVertex(v)
+		edges = append(edges,
+			Edge{StartVertex: v, EndVertex: ghost, Weight: 0},
+			Edge{StartVertex: ghost, EndVertex: v, Weight: 0})
+	}
+	for _, edge := range g.GetAllEdges() {
+		if edge.StartVertex == edge.EndVertex {
+			continue
+		}
+		if addOverflows(edge.Weight, ghostWeight) {
+			return SymmetricTransformation{}, ErrPathWeightOverflow
+		}
+		weight := edge.Weight + ghostWeight
+		if weight == noEdgeValue {
+			return SymmetricTransformation{}, fmt.Errorf("waga %d krawędzi %d -> %d po przekształceniu jest równa noEdgeValue grafu docelowego",
+				weight, edge.StartVertex, edge.EndVertex)
+		}
+		ghost := transformation.GhostVertex(edge.StartVertex)
+		edges = append(edges,
+			Edge{StartVertex: ghost, EndVertex: edge.EndVertex, Weight: weight},
+			Edge{StartVertex: edge.EndVertex, EndVertex: ghost, Weight: weight})
+	}
+
+	metadata := GetGraphMetadata(g)
+	metadata.Comment = fmt.Sprintf("Symetryczny odpowiednik ATSP (%d wierzchołków, M = %d, offset %d)",
+		vertexCount, ghostWeight, transformation.Offset)
+	metadata.VertexLabels = nil
+	if err := storeEdges(target, 2*vertexCount, edges, nil, metadata); err != nil {
+		return SymmetricTransformation{}, err
+	}
+
+	if floatWeights {
+		setter := target.(floatWeightSetter)
+		for _, edge := range g.GetAllEdges() {
+			if edge.StartVertex == edge.EndVertex {
+				continue
+			}
+			weight := EdgeWeightFloat(g, edge.StartVertex, edge.EndVertex) + float64(ghostWeight)
+			ghost := transformation.GhostVertex(edge.StartVertex)
+			setter.setWeightFloat(ghost, edge.EndVertex, weight)
+			setter.setWeightFloat(edge.EndVertex, ghost, weight)
+		}
+	}
+	return transformation, nil
+}
+
+// GhostVertex zwraca numer ducha wierzchołka v w grafie symetrycznym
+func (t SymmetricTransformation) GhostVertex(v int) int {
+	return t.VertexCount + v
+}
+
+// MapTourToSTSP zamienia trasę ATSP [v0, v1, ..., v0] na trasę symetryczną [v0, n+v0, v1, n+v1, ..., v0]
+func (t SymmetricTransformation) MapTourToSTSP(tour []int) []int {
+	if len(tour) == 0 {
+		return nil
+	}
+	symmetricTour := make([]int, 0, 2*len(tour))
+	for _, v := range tour[:len(tour)-1] {
+		symmetricTour = append(symmetricTour, v, t.GhostVertex(v))
+	}
+	return append(symmetricTour, tour[0])
+}
+
+// MapTourToATSP zamienia trasę w grafie symetrycznym (zamkniętą, o 2n+1 wierzchołkach, w dowolnym kierunku)
+// na trasę ATSP w grafie g i zwraca ją razem z kosztem liczonym jak w CalculatePathWeightChecked.
+// Trasa ATSP zaczyna się od pierwszego wierzchołka trasy symetrycznej (lub wierzchołka, którego jest on duchem).
+// Zwraca błąd, jeśli trasa nie przechodzi przez każdą krawędź {v, n+v} - nie odpowiada wtedy żadnej trasie ATSP.
+func (t SymmetricTransformation) MapTourToATSP(g Graph, tour []int) ([]int, int, error) {
+	n := t.VertexCount
+	if g.GetVertexCount() != n {
+		return nil, 0, fmt.Errorf("graf ma %d wierzchołków, a przekształcenie dotyczy %d", g.GetVertexCount(), n)
+	}
+	if len(tour) != 2*n+1 || tour[0] != tour[len(tour)-1] {
+		return nil, 0, fmt.Errorf("trasa symetryczna musi być zamknięta i zawierać %d wierzchołków", 2*n)
+	}
+	cycle := tour[:2*n]
+	position := make([]int, 2*n)
+	for i := range position {
+		position[i] = -1
+	}
+	for i, v := range cycle {
+		if v < 0 || v >= 2*n || position[v] != -1 {
+			return nil, 0, fmt.Errorf("nieprawidłowy lub powtórzony wierzchołek %d w trasie symetrycznej", v)
+		}
+		position[v] = i
+	}
+
+	// Kierunek obchodzenia: za każdym wierzchołkiem oryginalnym musi stać jego duch
+	start := tour[0]
+	if start >= n {
+		start -= n
+	}
+	step := 1
+	if cycle[(position[start]+1)%(2*n)] != t.GhostVertex(start) {
+		step = -1
+	}
+
+	atspTour := make([]int, 0, n+1)
+	p := position[start]
+	for k := 0; k < n; k++ {
+		v := cycle[p]
+		if v >= n || cycle[(p+step+2*n)%(2*n)] != t.GhostVertex(v) {
+			return nil, 0, errors.New("trasa symetryczna nie zawiera wszystkich krawędzi między wierzchołkami a ich duchami")
+		}
+		atspTour = append(atspTour, v)
+		p = (p + 2*step + 2*n) % (2 * n)
+	}
+	atspTour = append(atspTour, start)
+
+	cost, err := CalculatePathWeightChecked[int](g, atspTour)
+	if err != nil {
+		return nil, 0, err
+	}
+	return atspTour, cost, nil
+}
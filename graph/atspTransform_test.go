@@ -0,0 +1,126 @@
+package graph_test
+
+import (
+	"slices"
+	"testing"
+
+	"projekt2/graph"
+	"projekt2/solver/dp"
+)
+
+// asymmetricTestGraph tworzy graf ATSP o różnych wagach w obu kierunkach; pominięte łuki nie istnieją
+func asymmetricTestGraph(g graph.MatrixGraph, vertexCount int, missing [][2]int) graph.MatrixGraph {
+	for i := 0; i < vertexCount; i++ {
+		for j := 0; j < vertexCount; j++ {
+			if i != j {
+				g.AddEdge(i, j, (7*i+13*j)%17+1)
+			}
+		}
+	}
+	for _, arc := range missing {
+		g.RemoveEdge(arc[0], arc[1])
+	}
+	return g
+}
+
+func solveDP(g graph.Graph) ([]int, int) {
+	solver := dp.NewDynamicProgrammingATSPSolver(0)
+	solver.SetGraph(g)
+	return solver.Solve()
+}
+
+func TestTransformATSPToSTSP(t *testing.T) {
+	tests := []struct {
+		name   string
+		source graph.MatrixGraph
+		target graph.MatrixGraph
+	}{
+		{"pełny graf", asymmetricTestGraph(graph.NewAdjMatrixGraph(5, -1), 5, nil), graph.NewAdjMatrixGraph(0, -1)},
+		{"graf niepełny", asymmetricTestGraph(graph.NewAdjMatrixGraph(5, -1), 5, [][2]int{{0, 2}, {2, 0}, {3, 1}, {4, 0}}),
+			graph.NewAdjMatrixGraph(0, -1)},
+		{"listy sąsiedztwa", asymmetricTestGraph(graph.NewAdjListGraph(5, -1), 5, [][2]int{{1, 3}}), graph.NewAdjListGraph(0, -1)},
+		{"płaska macierz int32", asymmetricTestGraph(graph.NewAdjMatrixGraph(4, -1), 4, nil),
+			graph.NewFlatMatrixGraph(0, -1, graph.CellWidth32)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := tt.source.GetVertexCount()
+			transformation, err := graph.TransformATSPToSTSP(tt.source, tt.target)
+			if err != nil {
+				t.Fatalf("TransformATSPToSTSP: %v", err)
+			}
+			if tt.target.GetVertexCount() != 2*n || transformation.Offset != n*transformation.GhostWeight {
+				t.Fatalf("graf symetryczny ma %d wierzchołków, offset %d", tt.target.GetVertexCount(), transformation.Offset)
+			}
+			for i := 0; i < 2*n; i++ {
+				for j := 0; j < 2*n; j++ {
+					if tt.target.IsAdjacent(i, j) != tt.target.IsAdjacent(j, i) ||
+						tt.target.IsAdjacent(i, j) && tt.target.GetEdge(i, j).Weight != tt.target.GetEdge(j, i).Weight {
+						t.Fatalf("graf docelowy nie jest symetryczny dla pary %d, %d", i, j)
+					}
+				}
+			}
+
+			atspTour, atspCost := solveDP(tt.source)
+			symmetricTour, symmetricCost := solveDP(tt.target)
+			if atspTour == nil || symmetricTour == nil {
+				t.Fatal("DP nie znalazło trasy")
+			}
+			if symmetricCost-transformation.Offset != atspCost {
+				t.Errorf("koszt symetryczny %d - offset %d != koszt ATSP %d", symmetricCost, transformation.Offset, atspCost)
+			}
+
+			// Trasa symetryczna w obu kierunkach odpowiada trasie ATSP o optymalnym koszcie
+			reversed := slices.Clone(symmetricTour)
+			slices.Reverse(reversed)
+			for _, tour := range [][]int{symmetricTour, reversed} {
+				mapped, cost, err := transformation.MapTourToATSP(tt.source, tour)
+				if err != nil {
+					t.Fatalf("MapTourToATSP(%v): %v", tour, err)
+				}
+				if _, err := graph.EvaluateTour(tt.source, mapped); err != nil || cost != atspCost {
+					t.Errorf("trasa %v kosztuje %d, oczekiwano %d", mapped, cost, atspCost)
+				}
+			}
+
+			// Trasa ATSP przeniesiona do grafu symetrycznego kosztuje tyle samo plus offset i wraca bez zmian
+			lifted := transformation.MapTourToSTSP(atspTour)
+			liftedCost, err := graph.CalculatePathWeightChecked[int](tt.target, lifted)
+			if err != nil || liftedCost != atspCost+transformation.Offset {
+				t.Errorf("koszt trasy %v w grafie symetrycznym %d (%v), oczekiwano %d", lifted, liftedCost, err, atspCost+transformation.Offset)
+			}
+			back, _, err := transformation.MapTourToATSP(tt.source, lifted)
+			if err != nil || !slices.Equal(back, atspTour) {
+				t.Errorf("MapTourToATSP(MapTourToSTSP(%v)) = %v (%v)", atspTour, back, err)
+			}
+		})
+	}
+}
+
+func TestTransformATSPToSTSPErrors(t *testing.T) {
+	source := asymmetricTestGraph(graph.NewAdjMatrixGraph(3, -1), 3, nil)
+
+	if _, err := graph.TransformATSPToSTSP(source, graph.NewAdjMatrixGraph(0, 0)); err == nil {
+		t.Error("oczekiwano błędu dla noEdgeValue równego 0")
+	}
+	if _, err := graph.TransformATSPToSTSP(graph.NewAdjMatrixGraph(0, -1), graph.NewAdjMatrixGraph(0, -1)); err == nil {
+		t.Error("oczekiwano błędu dla pustego grafu")
+	}
+
+	transformation, err := graph.TransformATSPToSTSP(source, graph.NewAdjMatrixGraph(0, -1))
+	if err != nil {
+		t.Fatalf("TransformATSPToSTSP: %v", err)
+	}
+	invalidTours := map[string][]int{
+		"trasa otwarta":          {0, 3, 1, 4, 2, 5},
+		"zła długość":            {0, 3, 1, 4, 0},
+		"powtórzony wierzchołek": {0, 3, 1, 1, 2, 5, 0},
+		"rozdzielony duch":       {0, 1, 3, 4, 2, 5, 0},
+	}
+	for name, tour := range invalidTours {
+		if _, _, err := transformation.MapTourToATSP(source, tour); err == nil {
+			t.Errorf("%s: oczekiwano błędu dla trasy %v", name, tour)
+		}
+	}
+}
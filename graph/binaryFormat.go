@@ -0,0 +1,187 @@
+package graph
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+)
+
+// Format binarny (wszystkie liczby w kolejności little-endian):
+//
+//	magic        8 bajtów  "P2GRAPH\x00"
+//	version      uint16    wersja formatu (binaryFormatVersion)
+//	cellWidth    uint8     szerokość komórki macierzy w bajtach: 4 (int32) lub 8 (int64)
+//	reserved     uint8
+//	vertexCount  uint32
+//	noEdgeValue  int64
+//	nameLength   uint32, a następnie nazwa instancji (UTF-8)
+//	cells        vertexCount * vertexCount komórek, wiersz po wierszu
+//	checksum     uint32    CRC-32 (IEEE) wszystkich wcześniejszych bajtów
+const binaryFormatVersion = 1
+
+var binaryFormatMagic = []byte("P2GRAPH\x00")
+
+// binaryHeader to stała część nagłówka zapisywana bezpośrednio przez encoding/binary
+type binaryHeader struct {
+	Version     uint16
+	CellWidth   uint8
+	Reserved    uint8
+	VertexCount uint32
+	NoEdgeValue int64
+	NameLength  uint32
+}
+
+// SaveGraphToBinaryFile zapisuje graf w zwartym formacie binarnym.
+// Komórki są zapisywane jako int32, jeśli wszystkie wagi (oraz noEdgeValue) mieszczą się w tym zakresie,
+// a w przeciwnym wypadku jako int64. Jeśli ścieżka kończy się na .gz, plik jest dodatkowo kompresowany.
+// Komórki przechowują liczby całkowite, dlatego graf FloatGraph z wagą niecałkowitą jest odrzucany
+// (przed utworzeniem pliku) zamiast zapisywać zaokrąglone wagi.
+func SaveGraphToBinaryFile(g Graph, filePath string) error {
+	if err := checkIntegerWeights(g); err != nil {
+		return err
+	}
+	file, err := createOutputFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	if err := writeBinary(file, g); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// writeBinary zapisuje graf w formacie binarnym; wagi muszą być całkowite (patrz checkIntegerWeights)
+func writeBinary(w io.Writer, g Graph) error {
+	vertexCount := g.GetVertexCount()
+	if vertexCount > math.MaxUint32 {
+		return errors.New("graf jest zbyt duży dla formatu binarnego")
+	}
+
+	cellWidth := uint8(4)
+	if !fitsInInt32(g.GetNoEdgeValue()) {
+		cellWidth = 8
+	}
+	for i := 0; i < vertexCount && cellWidth == 4; i++ {
+		for j := 0; j < vertexCount; j++ {
+			if !fitsInInt32(g.GetEdge(i, j).Weight) {
+				cellWidth = 8
+				break
+			}
+		}
+	}
+
+	name := GetGraphMetadata(g).Name
+	checksum := crc32.NewIEEE()
+	out := bufio.NewWriter(w)
+	dst := io.MultiWriter(out, checksum)
+
+	header := binaryHeader{
+		Version:     binaryFormatVersion,
+		CellWidth:   cellWidth,
+		VertexCount: uint32(vertexCount),
+		NoEdgeValue: int64(g.GetNoEdgeValue()),
+		NameLength:  uint32(len(name)),
+	}
+	dst.Write(binaryFormatMagic)
+	if err := binary.Write(dst, binary.LittleEndian, header); err != nil {
+		return err
+	}
+	dst.Write([]byte(name))
+
+	// Cały wiersz jest kodowany do jednego bufora, aby uniknąć wywołań binary.Write dla każdej komórki
+	row := make([]byte, vertexCount*int(cellWidth))
+	for i := 0; i < vertexCount; i++ {
+		for j := 0; j < vertexCount; j++ {
+			weight := g.GetEdge(i, j).Weight
+			if cellWidth == 4 {
+				binary.LittleEndian.PutUint32(row[j*4:], uint32(int32(weight)))
+			} else {
+				binary.LittleEndian.PutUint64(row[j*8:], uint64(int64(weight)))
+			}
+		}
+		if _, err := dst.Write(row); err != nil {
+			return err
+		}
+	}
+
+	if err := binary.Write(out, binary.LittleEndian, checksum.Sum32()); err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+// loadBinaryFormat wczytuje graf z formatu binarnego i weryfikuje sumę kontrolną.
+// Wartość noEdgeValue z pliku zastępuje wartość grafu.
+func loadBinaryFormat(r io.Reader, graph MatrixGraph) error {
+	checksum := crc32.NewIEEE()
+	src := io.TeeReader(r, checksum)
+
+	magic := make([]byte, len(binaryFormatMagic))
+	if _, err := io.ReadFull(src, magic); err != nil || !bytes.Equal(magic, binaryFormatMagic) {
+		return errors.New("plik nie jest w formacie binarnym grafu")
+	}
+
+	var header binaryHeader
+	if err := binary.Read(src, binary.LittleEndian, &header); err != nil {
+		return errors.New("niepełny nagłówek pliku binarnego")
+	}
+	if header.Version != binaryFormatVersion {
+		return fmt.Errorf("nieobsługiwana wersja formatu binarnego: %d", header.Version)
+	}
+	if header.CellWidth != 4 && header.CellWidth != 8 {
+		return fmt.Errorf("nieprawidłowa szerokość komórki w pliku binarnym: %d", header.CellWidth)
+	}
+
+	name := make([]byte, header.NameLength)
+	if _, err := io.ReadFull(src, name); err != nil {
+		return errors.New("niepełna nazwa instancji w pliku binarnym")
+	}
+
+	// Wiersze są zapisywane bezpośrednio w grafie docelowym, bez pośredniej macierzy [][]int.
+	// noEdgeValue z pliku musi być ustawione przed zapisem wag, bo setWeight rozpoznaje po nim brak krawędzi.
+	vertexCount := int(header.VertexCount)
+	cellWidth := int(header.CellWidth)
+	graph.SetNoEdgeValue(int(header.NoEdgeValue))
+	graph.resetMatrix(vertexCount)
+	row := make([]byte, vertexCount*cellWidth)
+	for i := 0; i < vertexCount; i++ {
+		if _, err := io.ReadFull(src, row); err != nil {
+			return errors.New("zbyt mało danych w pliku binarnym aby uzupełnić macierz")
+		}
+		for j := 0; j < vertexCount; j++ {
+			var weight int
+			if cellWidth == 4 {
+				weight = int(int32(binary.LittleEndian.Uint32(row[j*4:])))
+			} else {
+				weight = int(int64(binary.LittleEndian.Uint64(row[j*8:])))
+			}
+			if !graph.setWeight(i, j, weight) {
+				return weightOverflowError(weight)
+			}
+		}
+	}
+
+	expected := checksum.Sum32()
+	var stored uint32
+	if err := binary.Read(r, binary.LittleEndian, &stored); err != nil {
+		return errors.New("brak sumy kontrolnej w pliku binarnym")
+	}
+	if stored != expected {
+		return errors.New("niezgodna suma kontrolna pliku binarnego - plik jest uszkodzony")
+	}
+
+	graph.SetCoordinates(nil)
+	graph.SetMetadata(Metadata{Name: string(name)})
+	return nil
+}
+
+func fitsInInt32(value int) bool {
+	return value >= math.MinInt32 && value <= math.MaxInt32
+}
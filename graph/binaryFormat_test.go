@@ -0,0 +1,126 @@
+package graph
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	sources := []struct {
+		name      string
+		graph     func() Graph
+		fitsInt32 bool
+	}{
+		{"trzy łuki i duże noEdgeValue", func() Graph {
+			g := NewAdjMatrixGraph(3, 100000000)
+			g.AddEdge(0, 1, 5)
+			g.AddEdge(1, 2, 0)
+			g.AddEdge(2, 0, -7)
+			g.SetMetadata(Metadata{Name: "trzy"})
+			return g
+		}, true},
+		{"pełny graf z ujemnym noEdgeValue", func() Graph {
+			g := NewAdjMatrixGraph(0, -1)
+			fillGeneratedGraph(g, 5, -1, "pelny", 1, func(i, j int) int { return 10*i + j })
+			return g
+		}, true},
+		{"wagi int64", func() Graph {
+			g := NewAdjListGraph(3, -1)
+			g.AddEdge(0, 1, 1<<40)
+			g.AddEdge(1, 0, 3)
+			return g
+		}, false},
+	}
+
+	for _, source := range sources {
+		for _, representation := range testRepresentations {
+			t.Run(source.name+"/"+representation, func(t *testing.T) {
+				want := source.graph()
+				filePath := filepath.Join(t.TempDir(), "graf.bin")
+				if err := SaveGraphToBinaryFile(want, filePath); err != nil {
+					t.Fatalf("SaveGraphToBinaryFile: %v", err)
+				}
+
+				// Graf docelowy ma inne noEdgeValue niż plik, jak grafy tworzone przez menu
+				got := newTestGraph(t, representation, 0)
+				format, err := LoadGraphFromFile(filePath, got)
+				if representation == RepresentationFlat32 && !source.fitsInt32 {
+					if err == nil {
+						t.Fatal("oczekiwano błędu dla wagi spoza zakresu int32")
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("LoadGraphFromFile: %v", err)
+				}
+				if format != FileFormatBinary {
+					t.Errorf("format %s, oczekiwano %s", format, FileFormatBinary)
+				}
+				assertSameWeights(t, got, want)
+				if name := got.GetMetadata().Name; name != GetGraphMetadata(want).Name {
+					t.Errorf("nazwa %q, oczekiwano %q", name, GetGraphMetadata(want).Name)
+				}
+			})
+		}
+	}
+}
+
+func TestBinaryFormatErrors(t *testing.T) {
+	source := NewAdjMatrixGraph(3, -1)
+	source.AddEdge(0, 1, 5)
+	var valid bytes.Buffer
+	if err := writeBinary(&valid, source); err != nil {
+		t.Fatalf("writeBinary: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(data []byte) []byte
+	}{
+		{"zmieniona waga", func(data []byte) []byte {
+			data[len(data)-8] ^= 1
+			return data
+		}},
+		{"brak sumy kontrolnej", func(data []byte) []byte { return data[:len(data)-4] }},
+		{"ucięta macierz", func(data []byte) []byte { return data[:len(data)-12] }},
+		{"zła wersja", func(data []byte) []byte {
+			data[len(binaryFormatMagic)] = 99
+			return data
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.modify(bytes.Clone(valid.Bytes()))
+			if err := loadBinaryFormat(bytes.NewReader(data), NewAdjMatrixGraph(0, -1)); err == nil {
+				t.Error("oczekiwano błędu")
+			}
+		})
+	}
+}
+
+func TestSaveGraphToBinaryFileFloatWeights(t *testing.T) {
+	g := NewFloatMatrixGraph(2, -1)
+	g.AddEdgeFloat(0, 1, 2.5)
+	g.AddEdgeFloat(1, 0, 3)
+	filePath := filepath.Join(t.TempDir(), "graf.bin")
+	if err := SaveGraphToBinaryFile(g, filePath); err == nil {
+		t.Error("oczekiwano błędu dla wagi niecałkowitej")
+	}
+	if _, err := os.Stat(filePath); !os.IsNotExist(err) {
+		t.Error("plik nie powinien zostać utworzony")
+	}
+
+	// Graf FloatGraph o wagach całkowitych jest zapisywany bez zmian
+	g.AddEdgeFloat(0, 1, 2)
+	if err := SaveGraphToBinaryFile(g, filePath); err != nil {
+		t.Fatalf("SaveGraphToBinaryFile: %v", err)
+	}
+	got := NewFloatMatrixGraph(0, 0)
+	if _, err := LoadGraphFromFile(filePath, got); err != nil {
+		t.Fatalf("LoadGraphFromFile: %v", err)
+	}
+	assertSameWeights(t, got, g)
+}
@@ -0,0 +1,387 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Metody wyznaczania list kandydatów
+const (
+	CandidatesNone    = "none"    // Brak list kandydatów - metaheurystyki rozważają wszystkie ruchy
+	CandidatesNearest = "nearest" // k najtańszych łuków wychodzących i wchodzących
+	CandidatesAlpha   = "alpha"   // k łuków o najmniejszej alfa-bliskości względem minimalnej 1-arborescencji
+)
+
+// CandidateLists przechowuje dla każdego wierzchołka listę obiecujących następników i poprzedników.
+// Dobre trasy prawie nie używają długich krawędzi, więc przeszukiwanie lokalne może ograniczyć ruchy
+// do takich, które tworzą łuki z list kandydatów.
+type CandidateLists struct {
+	Method   string
+	K        int
+	Outgoing [][]int     // Outgoing[v] - następnicy v, od najlepszego
+	Incoming [][]int     // Incoming[v] - poprzednicy v, od najlepszego
+	Alpha    [][]float64 // Alfa-bliskość łuków (tylko dla CandidatesAlpha, w przeciwnym razie nil)
+}
+
+// IsCandidate zwraca true, jeśli łuk startVertex -> endVertex należy do listy następników startVertex
+func (c *CandidateLists) IsCandidate(startVertex, endVertex int) bool {
+	for _, v := range c.Outgoing[startVertex] {
+		if v == endVertex {
+			return true
+		}
+	}
+	return false
+}
+
+// NewCandidateLists wyznacza listy kandydatów długości k podaną metodą (CandidatesNearest albo CandidatesAlpha).
+// Pętle i brakujące krawędzie nigdy nie są kandydatami, więc listy mogą być krótsze niż k.
+func NewCandidateLists(g Graph, method string, k int) (*CandidateLists, error) {
+	if k <= 0 {
+		return nil, errors.New("długość list kandydatów musi być dodatnia")
+	}
+	switch method {
+	case CandidatesNearest:
+		return nearestNeighborCandidates(g, k), nil
+	case CandidatesAlpha:
+		alpha, err := AlphaNearness(g)
+		if err != nil {
+			return nil, err
+		}
+		return alphaCandidates(g, alpha, k), nil
+	default:
+		return nil, fmt.Errorf("nieznana metoda list kandydatów: %s", method)
+	}
+}
+
+// nearestNeighborCandidates wybiera k najtańszych łuków wychodzących i wchodzących każdego wierzchołka
+func nearestNeighborCandidates(g Graph, k int) *CandidateLists {
+	vertexCount := g.GetVertexCount()
+	lists := &CandidateLists{
+		Method:   CandidatesNearest,
+		K:        k,
+		Outgoing: make([][]int, vertexCount),
+		Incoming: make([][]int, vertexCount),
+	}
+	for v := 0; v < vertexCount; v++ {
+		lists.Outgoing[v] = cheapestNeighbors(g, g.GetEdgesFromVertex(v), v, k, func(e Edge) int { return e.EndVertex })
+		lists.Incoming[v] = cheapestNeighbors(g, g.GetEdgesToVertex(v), v, k, func(e Edge) int { return e.StartVertex })
+	}
+	return lists
+}
+
+// cheapestNeighbors zwraca k sąsiadów v o najmniejszej wadze łuku (przy równych wagach - o mniejszym numerze)
+func cheapestNeighbors(g Graph, edges []Edge, v, k int, neighbor func(e Edge) int) []int {
+	type candidate struct {
+		vertex int
+		weight float64
+	}
+	candidates := make([]candidate, 0, len(edges))
+	for _, edge := range edges {
+		if u := neighbor(edge); u != v {
+			candidates = append(candidates, candidate{vertex: u, weight: EdgeWeightFloat(g, edge.StartVertex, edge.EndVertex)})
+		}
+	}
+	sort.Slice(candidates, func(a, b int) bool {
+		if candidates[a].weight != candidates[b].weight {
+			return candidates[a].weight < candidates[b].weight
+		}
+		return candidates[a].vertex < candidates[b].vertex
+	})
+	if len(candidates) > k {
+		candidates = candidates[:k]
+	}
+	result := make([]int, len(candidates))
+	for i, c := range candidates {
+		result[i] = c.vertex
+	}
+	return result
+}
+
+// alphaCandidates wybiera k łuków o najmniejszej alfa-bliskości (przy równej alfie - o mniejszej wadze)
+func alphaCandidates(g Graph, alpha [][]float64, k int) *CandidateLists {
+	vertexCount := g.GetVertexCount()
+	lists := &CandidateLists{
+		Method:   CandidatesAlpha,
+		K:        k,
+		Outgoing: make([][]int, vertexCount),
+		Incoming: make([][]int, vertexCount),
+		Alpha:    alpha,
+	}
+	byAlpha := func(vertices []int, arc func(u int) (int, int)) []int {
+		sort.Slice(vertices, func(a, b int) bool {
+			ia, ja := arc(vertices[a])
+			ib, jb := arc(vertices[b])
+			if alpha[ia][ja] != alpha[ib][jb] {
+				return alpha[ia][ja] < alpha[ib][jb]
+			}
+			return EdgeWeightFloat(g, ia, ja) < EdgeWeightFloat(g, ib, jb)
+		})
+		if len(vertices) > k {
+			vertices = vertices[:k]
+		}
+		return vertices
+	}
+	for v := 0; v < vertexCount; v++ {
+		var successors, predecessors []int
+		for u := 0; u < vertexCount; u++ {
+			if !math.IsInf(alpha[v][u], 1) {
+				successors = append(successors, u)
+			}
+			if !math.IsInf(alpha[u][v], 1) {
+				predecessors = append(predecessors, u)
+			}
+		}
+		lists.Outgoing[v] = byAlpha(successors, func(u int) (int, int) { return v, u })
+		lists.Incoming[v] = byAlpha(predecessors, func(u int) (int, int) { return u, v })
+	}
+	return lists
+}
+
+// AlphaNearness oblicza alfa-bliskość każdego łuku: o ile rośnie koszt minimalnej 1-arborescencji
+// (minimalnej arborescencji o korzeniu 0 z dołożonym najtańszym łukiem wchodzącym do 0), jeśli musi ona zawierać dany łuk.
+// Dla łuku i -> j, gdzie i nie jest potomkiem j, alfa jest dokładna (łuk zastępuje łuk wchodzący do j);
+// w przeciwnym razie dolicza się najtańszą naprawę poddrzewa j, co daje dolne oszacowanie.
+// Pętle i brakujące krawędzie mają alfę równą +Inf. Złożoność w najgorszym przypadku to O(n^3).
+func AlphaNearness(g Graph) ([][]float64, error) {
+	vertexCount := g.GetVertexCount()
+	if vertexCount < 2 {
+		return nil, errors.New("alfa-bliskość wymaga co najmniej dwóch wierzchołków")
+	}
+	weights, adjacent := WeightMatrix[float64](g)
+	for i := 0; i < vertexCount; i++ {
+		for j := 0; j < vertexCount; j++ {
+			if i == j || !adjacent[i][j] {
+				weights[i][j] = math.Inf(1)
+			}
+		}
+	}
+
+	const root = 0
+	parent, ok := minimumArborescence(weights, root)
+	if !ok {
+		return nil, errors.New("nie istnieje arborescencja o korzeniu 0 - nie każdy wierzchołek jest osiągalny")
+	}
+	rootIn := math.Inf(1)
+	for u := 0; u < vertexCount; u++ {
+		rootIn = math.Min(rootIn, weights[u][root])
+	}
+
+	// Numeracja pre-order i rozmiary poddrzew pozwalają sprawdzać w O(1), czy i jest potomkiem j
+	children := make([][]int, vertexCount)
+	for v := 0; v < vertexCount; v++ {
+		if v != root {
+			children[parent[v]] = append(children[parent[v]], v)
+		}
+	}
+	enter := make([]int, vertexCount)
+	leave := make([]int, vertexCount)
+	order := make([]int, 0, vertexCount)
+	stack := []int{root}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		enter[v] = len(order)
+		order = append(order, v)
+		stack = append(stack, children[v]...)
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		v := order[i]
+		leave[v] = enter[v] + 1
+		for _, c := range children[v] {
+			leave[v] = max(leave[v], leave[c])
+		}
+	}
+	isDescendant := func(i, j int) bool {
+		return enter[j] <= enter[i] && enter[i] < leave[j]
+	}
+
+	// repair[j] - najtańsza zamiana łuku wchodzącego do wierzchołka poddrzewa j (poza j) na łuk spoza poddrzewa
+	repair := make([]float64, vertexCount)
+	repairKnown := make([]bool, vertexCount)
+	repairCost := func(j int) float64 {
+		if repairKnown[j] {
+			return repair[j]
+		}
+		best := math.Inf(1)
+		for _, v := range order[enter[j]+1 : leave[j]] {
+			for u := 0; u < vertexCount; u++ {
+				if !isDescendant(u, j) && !math.IsInf(weights[u][v], 1) {
+					best = math.Min(best, weights[u][v]-weights[parent[v]][v])
+				}
+			}
+		}
+		repair[j], repairKnown[j] = best, true
+		return best
+	}
+
+	alpha := make([][]float64, vertexCount)
+	for i := 0; i < vertexCount; i++ {
+		alpha[i] = make([]float64, vertexCount)
+		for j := 0; j < vertexCount; j++ {
+			switch {
+			case math.IsInf(weights[i][j], 1):
+				alpha[i][j] = math.Inf(1)
+			case j == root:
+				alpha[i][j] = weights[i][j] - rootIn
+			case !isDescendant(i, j):
+				alpha[i][j] = weights[i][j] - weights[parent[j]][j]
+			default:
+				alpha[i][j] = math.Max(0, weights[i][j]-weights[parent[j]][j]+repairCost(j))
+			}
+		}
+	}
+	return alpha, nil
+}
+
+// minimumArborescence zwraca tablicę poprzedników minimalnej arborescencji o korzeniu root (algorytm Chu-Liu/Edmondsa).
+// Brakujące łuki mają wagę +Inf. Zwraca false, jeśli któryś wierzchołek jest nieosiągalny z korzenia.
+func minimumArborescence(weights [][]float64, root int) ([]int, bool) {
+	vertexCount := len(weights)
+
+	// Najtańszy łuk wchodzący do każdego wierzchołka
+	parent := make([]int, vertexCount)
+	for v := 0; v < vertexCount; v++ {
+		parent[v] = -1
+		if v == root {
+			continue
+		}
+		best := math.Inf(1)
+		for u := 0; u < vertexCount; u++ {
+			if u != v && weights[u][v] < best {
+				best = weights[u][v]
+				parent[v] = u
+			}
+		}
+		if parent[v] == -1 {
+			return nil, false
+		}
+	}
+
+	// Wykrywanie cykli w grafie wybranych łuków
+	cycleID := make([]int, vertexCount)
+	visitedBy := make([]int, vertexCount)
+	for v := range cycleID {
+		cycleID[v] = -1
+		visitedBy[v] = -1
+	}
+	cycleCount := 0
+	for v := 0; v < vertexCount; v++ {
+		u := v
+		for u != root && visitedBy[u] == -1 && cycleID[u] == -1 {
+			visitedBy[u] = v
+			u = parent[u]
+		}
+		if u != root && visitedBy[u] == v && cycleID[u] == -1 {
+			for x := u; cycleID[x] == -1; x = parent[x] {
+				cycleID[x] = cycleCount
+			}
+			cycleCount++
+		}
+	}
+	if cycleCount == 0 {
+		return parent, true
+	}
+
+	// Ściągnięcie każdego cyklu do jednego wierzchołka; łuk wchodzący do cyklu jest pomniejszany
+	// o wagę łuku cyklu, który zastępuje
+	newID := make([]int, vertexCount)
+	contractedCount := cycleCount
+	for v := 0; v < vertexCount; v++ {
+		if cycleID[v] >= 0 {
+			newID[v] = cycleID[v]
+		} else {
+			newID[v] = contractedCount
+			contractedCount++
+		}
+	}
+	contracted := make([][]float64, contractedCount)
+	originFrom := make([][]int, contractedCount)
+	originTo := make([][]int, contractedCount)
+	for a := 0; a < contractedCount; a++ {
+		contracted[a] = make([]float64, contractedCount)
+		originFrom[a] = make([]int, contractedCount)
+		originTo[a] = make([]int, contractedCount)
+		for b := range contracted[a] {
+			contracted[a][b] = math.Inf(1)
+		}
+	}
+	for u := 0; u < vertexCount; u++ {
+		for v := 0; v < vertexCount; v++ {
+			a, b := newID[u], newID[v]
+			if a == b || math.IsInf(weights[u][v], 1) {
+				continue
+			}
+			weight := weights[u][v]
+			if cycleID[v] >= 0 {
+				weight -= weights[parent[v]][v]
+			}
+			if weight < contracted[a][b] {
+				contracted[a][b] = weight
+				originFrom[a][b] = u
+				originTo[a][b] = v
+			}
+		}
+	}
+
+	contractedParent, ok := minimumArborescence(contracted, newID[root])
+	if !ok {
+		return nil, false
+	}
+
+	// Rozwinięcie: łuki cykli zostają, poza łukiem zastąpionym przez łuk wchodzący do cyklu
+	result := make([]int, vertexCount)
+	copy(result, parent)
+	for b := 0; b < contractedCount; b++ {
+		if b == newID[root] {
+			continue
+		}
+		a := contractedParent[b]
+		result[originTo[a][b]] = originFrom[a][b]
+	}
+	result[root] = -1
+	return result, true
+}
+
+// CandidateCache przechowuje listy kandydatów policzone dla jednego grafu, aby kolejne uruchomienia
+// metaheurystyk nie wyznaczały ich ponownie. Zmiana grafu lub jego noEdgeValue unieważnia zapamiętane listy;
+// po innej modyfikacji wag należy wywołać Clear.
+type CandidateCache struct {
+	graph       Graph
+	noEdgeValue int
+	lists       map[candidateKey]*CandidateLists
+}
+
+type candidateKey struct {
+	method string
+	k      int
+}
+
+// Get zwraca listy kandydatów dla grafu, wyznaczając je tylko przy pierwszym użyciu
+func (c *CandidateCache) Get(g Graph, method string, k int) (*CandidateLists, error) {
+	if c.graph != g || c.noEdgeValue != g.GetNoEdgeValue() {
+		c.Clear()
+		c.graph = g
+		c.noEdgeValue = g.GetNoEdgeValue()
+	}
+	key := candidateKey{method: method, k: k}
+	if lists, ok := c.lists[key]; ok {
+		return lists, nil
+	}
+	lists, err := NewCandidateLists(g, method, k)
+	if err != nil {
+		return nil, err
+	}
+	if c.lists == nil {
+		c.lists = make(map[candidateKey]*CandidateLists)
+	}
+	c.lists[key] = lists
+	return lists, nil
+}
+
+// Clear usuwa zapamiętane listy kandydatów
+func (c *CandidateCache) Clear() {
+	c.graph = nil
+	c.lists = nil
+}
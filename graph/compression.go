@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"strings"
+)
+
+var (
+	gzipMagic  = []byte{0x1f, 0x8b}
+	bzip2Magic = []byte("BZh")
+)
+
+// inputFile łączy strumień (ewentualnie dekompresowany) z plikami, które trzeba zamknąć po odczycie
+type inputFile struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (f *inputFile) Close() error {
+	var firstErr error
+	for i := len(f.closers) - 1; i >= 0; i-- {
+		if err := f.closers[i].Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// openInputFile otwiera plik do odczytu i w razie potrzeby dekompresuje go w locie.
+// Kompresja gzip i bzip2 jest rozpoznawana po sygnaturze na początku pliku, więc działa niezależnie od rozszerzenia
+// (np. .atsp.gz, .tsp.bz2), a pliki bez kompresji są czytane bez zmian.
+func openInputFile(filePath string) (io.ReadCloser, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	buffered := bufio.NewReader(file)
+	magic, err := buffered.Peek(3)
+	if err != nil && err != io.EOF {
+		file.Close()
+		return nil, err
+	}
+
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		gzipReader, err := gzip.NewReader(buffered)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		return &inputFile{Reader: gzipReader, closers: []io.Closer{file, gzipReader}}, nil
+	case bytes.HasPrefix(magic, bzip2Magic):
+		return &inputFile{Reader: bzip2.NewReader(buffered), closers: []io.Closer{file}}, nil
+	}
+	return &inputFile{Reader: buffered, closers: []io.Closer{file}}, nil
+}
+
+// outputFile łączy strumień kompresujący z plikiem docelowym
+type outputFile struct {
+	io.Writer
+	closers []io.Closer
+}
+
+// Close zamyka kompresor (zapisując jego bufory) i dopiero potem plik
+func (f *outputFile) Close() error {
+	var firstErr error
+	for i := len(f.closers) - 1; i >= 0; i-- {
+		if err := f.closers[i].Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// createOutputFile tworzy plik do zapisu; dla rozszerzenia .gz dane są kompresowane algorytmem gzip.
+// Biblioteka standardowa nie obsługuje kompresji bzip2, więc zapis do pliku .bz2 zwraca błąd.
+func createOutputFile(filePath string) (io.WriteCloser, error) {
+	lowerPath := strings.ToLower(filePath)
+	if strings.HasSuffix(lowerPath, ".bz2") {
+		return nil, errors.New("zapis w formacie bzip2 nie jest obsługiwany, użyj rozszerzenia .gz")
+	}
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasSuffix(lowerPath, ".gz") {
+		gzipWriter := gzip.NewWriter(file)
+		return &outputFile{Writer: gzipWriter, closers: []io.Closer{file, gzipWriter}}, nil
+	}
+	return &outputFile{Writer: file, closers: []io.Closer{file}}, nil
+}
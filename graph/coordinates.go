@@ -0,0 +1,131 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+)
+
+// Typy wag krawędzi (EDGE_WEIGHT_TYPE) z TSPLIB wyliczane na podstawie współrzędnych
+const (
+	EdgeWeightTypeExplicit = "EXPLICIT"
+	EdgeWeightTypeEuc2D    = "EUC_2D"
+	EdgeWeightTypeCeil2D   = "CEIL_2D"
+	EdgeWeightTypeAtt      = "ATT"
+	EdgeWeightTypeGeo      = "GEO"
+	EdgeWeightTypeMan2D    = "MAN_2D"
+	EdgeWeightTypeMax2D    = "MAX_2D"
+)
+
+// Coordinate to położenie wierzchołka na płaszczyźnie.
+// Dla instancji typu GEO X oznacza szerokość, a Y długość geograficzną w formacie DDD.MM (stopnie.minuty).
+type Coordinate struct {
+	X float64
+	Y float64
+}
+
+// CoordinateGraph jest implementowany przez grafy, które pamiętają współrzędne wierzchołków
+type CoordinateGraph interface {
+	Graph
+	GetCoordinates() []Coordinate
+}
+
+// DistanceFunction oblicza wagę krawędzi między dwoma punktami
+type DistanceFunction func(from, to Coordinate) int
+
+// DistanceFunctionForType zwraca funkcję odległości zgodną z regułami zaokrąglania TSPLIB dla danego EDGE_WEIGHT_TYPE
+func DistanceFunctionForType(edgeWeightType string) (DistanceFunction, error) {
+	switch edgeWeightType {
+	case EdgeWeightTypeEuc2D:
+		return euc2DDistance, nil
+	case EdgeWeightTypeCeil2D:
+		return ceil2DDistance, nil
+	case EdgeWeightTypeAtt:
+		return attDistance, nil
+	case EdgeWeightTypeGeo:
+		return geoDistance, nil
+	case EdgeWeightTypeMan2D:
+		return man2DDistance, nil
+	case EdgeWeightTypeMax2D:
+		return max2DDistance, nil
+	}
+	return nil, fmt.Errorf("nieobsługiwany typ EDGE_WEIGHT_TYPE: %s", edgeWeightType)
+}
+
+// BuildDistanceMatrix tworzy macierz odległości między wszystkimi parami punktów.
+// Na przekątnej wpisywana jest wartość noEdgeValue (brak krawędzi do samego siebie).
+func BuildDistanceMatrix(coordinates []Coordinate, distance DistanceFunction, noEdgeValue int) [][]int {
+	vertexCount := len(coordinates)
+	matrix := make([][]int, vertexCount)
+	for i := 0; i < vertexCount; i++ {
+		matrix[i] = make([]int, vertexCount)
+		for j := 0; j < vertexCount; j++ {
+			if i == j {
+				matrix[i][j] = noEdgeValue
+			} else {
+				matrix[i][j] = distance(coordinates[i], coordinates[j])
+			}
+		}
+	}
+	return matrix
+}
+
+// nint zaokrągla do najbliższej liczby całkowitej tak jak w implementacji referencyjnej TSPLIB
+func nint(x float64) int {
+	return int(x + 0.5)
+}
+
+func euc2DDistance(from, to Coordinate) int {
+	dx := from.X - to.X
+	dy := from.Y - to.Y
+	return nint(math.Sqrt(dx*dx + dy*dy))
+}
+
+func ceil2DDistance(from, to Coordinate) int {
+	dx := from.X - to.X
+	dy := from.Y - to.Y
+	return int(math.Ceil(math.Sqrt(dx*dx + dy*dy)))
+}
+
+func man2DDistance(from, to Coordinate) int {
+	return nint(math.Abs(from.X-to.X) + math.Abs(from.Y-to.Y))
+}
+
+func max2DDistance(from, to Coordinate) int {
+	dx := nint(math.Abs(from.X - to.X))
+	dy := nint(math.Abs(from.Y - to.Y))
+	if dx > dy {
+		return dx
+	}
+	return dy
+}
+
+// attDistance to pseudo-euklidesowa odległość stosowana w instancjach att48 i att532
+func attDistance(from, to Coordinate) int {
+	dx := from.X - to.X
+	dy := from.Y - to.Y
+	r := math.Sqrt((dx*dx + dy*dy) / 10.0)
+	t := nint(r)
+	if float64(t) < r {
+		return t + 1
+	}
+	return t
+}
+
+// geoRadians zamienia współrzędną w formacie DDD.MM na radiany (stała PI jak w TSPLIB)
+func geoRadians(x float64) float64 {
+	const pi = 3.141592
+	deg := math.Trunc(x)
+	min := x - deg
+	return pi * (deg + 5.0*min/3.0) / 180.0
+}
+
+// geoDistance to odległość po powierzchni idealnej kuli o promieniu 6378.388 km
+func geoDistance(from, to Coordinate) int {
+	const rrr = 6378.388
+	latFrom, lonFrom := geoRadians(from.X), geoRadians(from.Y)
+	latTo, lonTo := geoRadians(to.X), geoRadians(to.Y)
+	q1 := math.Cos(lonFrom - lonTo)
+	q2 := math.Cos(latFrom - latTo)
+	q3 := math.Cos(latFrom + latTo)
+	return int(rrr*math.Acos(0.5*((1.0+q1)*q2-(1.0-q1)*q3)) + 1.0)
+}
@@ -0,0 +1,124 @@
+package graph
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDistanceFunctionForType(t *testing.T) {
+	tests := []struct {
+		name           string
+		edgeWeightType string
+		from, to       Coordinate
+		want           int
+	}{
+		{"EUC_2D całkowita", EdgeWeightTypeEuc2D, Coordinate{0, 0}, Coordinate{3, 4}, 5},
+		{"EUC_2D w dół", EdgeWeightTypeEuc2D, Coordinate{0, 0}, Coordinate{1, 1}, 1},
+		{"EUC_2D połowa w górę", EdgeWeightTypeEuc2D, Coordinate{0, 0}, Coordinate{1.5, 2}, 3},
+		{"CEIL_2D", EdgeWeightTypeCeil2D, Coordinate{0, 0}, Coordinate{1, 1}, 2},
+		{"CEIL_2D całkowita", EdgeWeightTypeCeil2D, Coordinate{0, 0}, Coordinate{3, 4}, 5},
+		{"ATT att48 1-2", EdgeWeightTypeAtt, Coordinate{6734, 1453}, Coordinate{2233, 10}, 1495},
+		{"ATT zaokrąglenie w górę", EdgeWeightTypeAtt, Coordinate{0, 0}, Coordinate{10, 0}, 4},
+		{"MAN_2D", EdgeWeightTypeMan2D, Coordinate{0, 0}, Coordinate{1.2, 2.4}, 4},
+		{"MAX_2D", EdgeWeightTypeMax2D, Coordinate{0, 0}, Coordinate{1.2, 2.6}, 3},
+		{"GEO burma14 1-2", EdgeWeightTypeGeo, Coordinate{16.47, 96.10}, Coordinate{16.47, 94.44}, 153},
+		{"GEO burma14 1-3", EdgeWeightTypeGeo, Coordinate{16.47, 96.10}, Coordinate{20.09, 92.54}, 510},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			distance, err := DistanceFunctionForType(tt.edgeWeightType)
+			if err != nil {
+				t.Fatalf("DistanceFunctionForType: %v", err)
+			}
+			if got := distance(tt.from, tt.to); got != tt.want {
+				t.Errorf("odległość %d, oczekiwano %d", got, tt.want)
+			}
+			if got := distance(tt.to, tt.from); got != tt.want {
+				t.Errorf("odległość w przeciwnym kierunku %d, oczekiwano %d", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := DistanceFunctionForType("GEOM"); err == nil {
+		t.Error("oczekiwano błędu dla nieobsługiwanego typu")
+	}
+}
+
+// burma14 z TSPLIB; optymalna trasa ma koszt 3323
+const burma14TSPLIB = `NAME: burma14
+TYPE: TSP
+COMMENT: 14-Staedte in Burma (Zaw Win)
+DIMENSION: 14
+EDGE_WEIGHT_TYPE: GEO
+EDGE_WEIGHT_FORMAT: FUNCTION
+DISPLAY_DATA_TYPE: COORD_DISPLAY
+NODE_COORD_SECTION
+   1  16.47       96.10
+   2  16.47       94.44
+   3  20.09       92.54
+   4  22.39       93.37
+   5  25.23       97.24
+   6  22.00       96.05
+   7  20.47       97.02
+   8  17.20       96.29
+   9  16.30       97.38
+  10  14.05       98.12
+  11  16.53       97.38
+  12  21.52       95.59
+  13  19.41       97.13
+  14  20.09       94.55
+EOF
+`
+
+func TestLoadCoordinateTSPLIB(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "burma14.tsp")
+	if err := os.WriteFile(filePath, []byte(burma14TSPLIB), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	graphs := []struct {
+		name  string
+		graph MatrixGraph
+	}{
+		{"macierz sąsiedztwa", NewAdjMatrixGraph(0, -1)},
+		{"płaska macierz int32", NewFlatMatrixGraph(0, -1, CellWidth32)},
+		{"listy sąsiedztwa", NewAdjListGraph(0, -1)},
+	}
+	optimalTour := []int{0, 1, 13, 2, 3, 4, 5, 11, 6, 12, 7, 10, 8, 9, 0}
+
+	for _, tt := range graphs {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := LoadGraphFromFile(filePath, tt.graph)
+			if err != nil {
+				t.Fatalf("LoadGraphFromFile: %v", err)
+			}
+			if format != FileFormatTSPLIBCoordinates {
+				t.Errorf("format %s, oczekiwano %s", format, FileFormatTSPLIBCoordinates)
+			}
+			if n := tt.graph.GetVertexCount(); n != 14 {
+				t.Fatalf("liczba wierzchołków %d, oczekiwano 14", n)
+			}
+			if w := tt.graph.GetEdge(0, 1).Weight; w != 153 {
+				t.Errorf("waga 0 -> 1 = %d, oczekiwano 153", w)
+			}
+			for v := 0; v < 14; v++ {
+				if tt.graph.IsAdjacent(v, v) {
+					t.Errorf("pętla w wierzchołku %d", v)
+				}
+			}
+			if coordinates := tt.graph.GetCoordinates(); len(coordinates) != 14 || coordinates[2] != (Coordinate{20.09, 92.54}) {
+				t.Errorf("nieprawidłowe współrzędne: %v", coordinates)
+			}
+			metadata := tt.graph.GetMetadata()
+			if metadata.Name != "burma14" || metadata.EdgeWeightType != EdgeWeightTypeGeo {
+				t.Errorf("nieprawidłowe metadane: %+v", metadata)
+			}
+			cost, err := CalculatePathWeightChecked[int](tt.graph, optimalTour)
+			if err != nil || cost != 3323 {
+				t.Errorf("koszt trasy optymalnej %d (%v), oczekiwano 3323", cost, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CSVEdgeListOptions określa sposób interpretacji pliku CSV z listą krawędzi "from,to,weight"
+type CSVEdgeListOptions struct {
+	// MapVertexLabels wymusza traktowanie identyfikatorów wierzchołków jako etykiet i numerowanie ich od 0
+	// w kolejności pierwszego wystąpienia. Bez tej opcji etykiety są mapowane tylko wtedy,
+	// gdy któryś identyfikator nie jest liczbą całkowitą; w przeciwnym wypadku liczby są indeksami wierzchołków.
+	MapVertexLabels bool
+}
+
+// LoadGraphFromCSVEdgeList wczytuje graf z pliku CSV z listą krawędzi (from,to,weight).
+// Separatorem może być przecinek lub średnik, a opcjonalny wiersz nagłówka jest pomijany.
+// Pary wierzchołków nieobecne w pliku otrzymują wartość noEdgeValue grafu.
+// Przy mapowaniu etykiet ich oryginalne wartości trafiają do Metadata.VertexLabels.
+func LoadGraphFromCSVEdgeList(filePath string, graph MatrixGraph, options CSVEdgeListOptions) error {
+	file, err := openInputFile(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return loadCSVEdgeListFormat(bufio.NewReader(file), graph, options)
+}
+
+// SaveGraphToCSVEdgeList zapisuje wszystkie krawędzie grafu (GetAllEdges) do pliku CSV "from,to,weight".
+// Jeśli graf ma etykiety wierzchołków w metadanych, są one zapisywane zamiast indeksów.
+// Jeśli ścieżka kończy się na .gz, plik jest kompresowany algorytmem gzip.
+func SaveGraphToCSVEdgeList(g Graph, filePath string) error {
+	file, err := createOutputFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	labels := GetGraphMetadata(g).VertexLabels
+	vertexName := func(vertex int) string {
+		if len(labels) == g.GetVertexCount() {
+			return labels[vertex]
+		}
+		return strconv.Itoa(vertex)
+	}
+
+	out := bufio.NewWriter(file)
+	writer := csv.NewWriter(out)
+	writer.Write([]string{"from", "to", "weight"})
+	for _, edge := range g.GetAllEdges() {
+		writer.Write([]string{vertexName(edge.StartVertex), vertexName(edge.EndVertex), formatEdgeWeight(g, edge.StartVertex, edge.EndVertex)})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := out.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// csvEdge to krawędź odczytana z pliku przed przypisaniem indeksów wierzchołkom
+type csvEdge struct {
+	from, to    string
+	weight      int
+	floatWeight string // Waga niecałkowita (tylko dla grafów zmiennoprzecinkowych), pusta dla wag całkowitych
+}
+
+// loadCSVEdgeListFormat wczytuje listę krawędzi CSV do grafu
+func loadCSVEdgeListFormat(reader *bufio.Reader, graph MatrixGraph, options CSVEdgeListOptions) error {
+	header, err := reader.Peek(reader.Size())
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	csvReader := csv.NewReader(reader)
+	csvReader.Comma = detectCSVSeparator(string(header))
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	edges := make([]csvEdge, 0)
+	allNumeric := true
+	for row := 0; ; row++ {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("błąd odczytu pliku CSV: %v", err)
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		if len(record) < 3 {
+			return fmt.Errorf("wiersz %d pliku CSV nie zawiera trzech kolumn from,to,weight", row+1)
+		}
+
+		weightText := strings.TrimSpace(record[2])
+		floatWeight := ""
+		weight, err := strconv.Atoi(weightText)
+		if err != nil {
+			_, floatErr := strconv.ParseFloat(weightText, 64)
+			if floatErr != nil && row == 0 {
+				// Pierwszy wiersz z nienumeryczną wagą to nagłówek
+				continue
+			}
+			if _, ok := graph.(floatWeightSetter); floatErr != nil || !ok {
+				return fmt.Errorf("błąd konwersji wagi w wierszu %d pliku CSV", row+1)
+			}
+			floatWeight = weightText
+		}
+
+		edge := csvEdge{from: strings.TrimSpace(record[0]), to: strings.TrimSpace(record[1]), weight: weight, floatWeight: floatWeight}
+		for _, id := range []string{edge.from, edge.to} {
+			if _, err := strconv.Atoi(id); err != nil {
+				allNumeric = false
+			}
+		}
+		edges = append(edges, edge)
+	}
+	if len(edges) == 0 {
+		return errors.New("plik CSV nie zawiera żadnych krawędzi")
+	}
+
+	var labels []string
+	indices := make(map[string]int)
+	vertexCount := 0
+	if options.MapVertexLabels || !allNumeric {
+		for _, edge := range edges {
+			for _, id := range []string{edge.from, edge.to} {
+				if _, ok := indices[id]; !ok {
+					indices[id] = len(labels)
+					labels = append(labels, id)
+				}
+			}
+		}
+		vertexCount = len(labels)
+	} else {
+		for _, edge := range edges {
+			for _, id := range []string{edge.from, edge.to} {
+				vertex, _ := strconv.Atoi(id)
+				if vertex < 0 {
+					return fmt.Errorf("ujemny numer wierzchołka w pliku CSV: %d", vertex)
+				}
+				indices[id] = vertex
+				if vertex+1 > vertexCount {
+					vertexCount = vertex + 1
+				}
+			}
+		}
+	}
+
+	graphEdges := make([]Edge, 0, len(edges))
+	for _, edge := range edges {
+		if edge.floatWeight == "" {
+			graphEdges = append(graphEdges, Edge{StartVertex: indices[edge.from], EndVertex: indices[edge.to], Weight: edge.weight})
+		}
+	}
+
+	if err := storeEdges(graph, vertexCount, graphEdges, nil, Metadata{VertexLabels: labels}); err != nil {
+		return err
+	}
+	for _, edge := range edges {
+		if edge.floatWeight != "" {
+			if err := setFloatWeight(graph, indices[edge.from], indices[edge.to], edge.floatWeight); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// detectCSVSeparator wybiera średnik, jeśli pierwsza linia zawiera średniki, a nie zawiera przecinków
+func detectCSVSeparator(text string) rune {
+	if idx := strings.IndexByte(text, '\n'); idx >= 0 {
+		text = text[:idx]
+	}
+	if strings.Contains(text, ";") && !strings.Contains(text, ",") {
+		return ';'
+	}
+	return ','
+}
@@ -0,0 +1,303 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+)
+
+// Generatory klas instancji ATSP z DIMACS Implementation Challenge (Johnson i in.).
+// Każdy generator nadpisuje zawartość grafu g, wpisuje noEdgeValue na przekątną
+// i zapisuje nazwę klasy, liczbę wierzchołków oraz ziarno w metadanych grafu.
+// To samo ziarno (seed) daje zawsze tę samą instancję; nowe ziarno zwraca NewSeed.
+// Wszystkie generowane wagi są nieujemne (odległości, czasy, długości), a niektóre klasy (amat, rtilt
+// i stilt bez kosztu zjazdu, disk, coin, punkty o zerowej odległości) dają łuki o wadze 0, dlatego
+// generatory odrzucają nieujemne noEdgeValue - takie łuki stałyby się brakiem krawędzi.
+
+// fillGeneratedGraph wypełnia graf wagami wyliczonymi przez funkcję weight dla każdej pary i != j
+func fillGeneratedGraph(g MatrixGraph, vertexCount, noEdgeValue int, name string, seed int64, weight func(i, j int) int) error {
+	if err := checkGeneratorNoEdgeValue(noEdgeValue); err != nil {
+		return err
+	}
+	// noEdgeValue jest ustawiane przed wypełnieniem, bo setWeight rozpoznaje po nim brak krawędzi
+	g.SetNoEdgeValue(noEdgeValue)
+	g.resetMatrix(vertexCount)
+	for i := 0; i < vertexCount; i++ {
+		for j := 0; j < vertexCount; j++ {
+			if i == j {
+				g.setWeight(i, j, noEdgeValue)
+			} else {
+				g.setWeight(i, j, weight(i, j))
+			}
+		}
+	}
+	g.SetCoordinates(nil)
+	g.SetMetadata(Metadata{Name: name + strconv.Itoa(vertexCount), Comment: seedComment("DIMACS ATSP: "+name, seed), Seed: seed})
+	return nil
+}
+
+// checkGeneratorNoEdgeValue odrzuca noEdgeValue z zakresu wag generatorów (wagi >= 0)
+func checkGeneratorNoEdgeValue(noEdgeValue int) error {
+	if noEdgeValue >= 0 {
+		return fmt.Errorf("wartość braku krawędzi %d mieści się w zakresie generowanych wag (>= 0) - podaj wartość ujemną, np. -1", noEdgeValue)
+	}
+	return nil
+}
+
+// randomPoints losuje punkty o współrzędnych całkowitych z kwadratu [0, squareSize) x [0, squareSize)
+func randomPoints(rng *rand.Rand, count, squareSize int) []Coordinate {
+	points := make([]Coordinate, count)
+	for i := range points {
+		points[i] = Coordinate{X: float64(rng.Intn(squareSize)), Y: float64(rng.Intn(squareSize))}
+	}
+	return points
+}
+
+// GenerateAmatGraph generuje klasę amat: niezależne losowe wagi z zakresu [0, maxWeight)
+func GenerateAmatGraph(g MatrixGraph, vertexCount, noEdgeValue, maxWeight int, seed int64) error {
+	rng := newSeededRand(seed)
+	return fillGeneratedGraph(g, vertexCount, noEdgeValue, "amat", seed, func(i, j int) int {
+		return rng.Intn(maxWeight)
+	})
+}
+
+// GenerateTmatGraph generuje klasę tmat: macierz amat domkniętą względem najkrótszych ścieżek
+// (algorytm Floyda-Warshalla), dzięki czemu spełnia nierówność trójkąta
+func GenerateTmatGraph(g MatrixGraph, vertexCount, noEdgeValue, maxWeight int, seed int64) error {
+	if err := GenerateAmatGraph(g, vertexCount, noEdgeValue, maxWeight, seed); err != nil {
+		return err
+	}
+	for k := 0; k < vertexCount; k++ {
+		for i := 0; i < vertexCount; i++ {
+			if i == k {
+				continue
+			}
+			for j := 0; j < vertexCount; j++ {
+				if j == i || j == k {
+					continue
+				}
+				if viaK := g.GetEdge(i, k).Weight + g.GetEdge(k, j).Weight; viaK < g.GetEdge(i, j).Weight {
+					g.setWeight(i, j, viaK)
+				}
+			}
+		}
+	}
+	g.SetMetadata(Metadata{Name: "tmat" + strconv.Itoa(vertexCount), Comment: seedComment("DIMACS ATSP: tmat", seed), Seed: seed})
+	return nil
+}
+
+// GenerateRectGraph generuje klasę rect (instancja symetryczna): punkty w kwadracie squareSize x squareSize,
+// waga = odległość prostokątna (metryka miejska) |dx| + |dy|
+func GenerateRectGraph(g MatrixGraph, vertexCount, noEdgeValue, squareSize int, seed int64) error {
+	points := randomPoints(newSeededRand(seed), vertexCount, squareSize)
+	if err := fillGeneratedGraph(g, vertexCount, noEdgeValue, "rect", seed, func(i, j int) int {
+		return man2DDistance(points[i], points[j])
+	}); err != nil {
+		return err
+	}
+	g.SetCoordinates(points)
+	return nil
+}
+
+// tiltedVerticalCost to koszt ruchu w pionie na pochylonym stole: w górę kosztuje upFactor, w dół downFactor za jednostkę
+func tiltedVerticalCost(from, to Coordinate, upFactor, downFactor int) int {
+	dy := int(to.Y - from.Y)
+	if dy >= 0 {
+		return upFactor * dy
+	}
+	return -downFactor * dy
+}
+
+// GenerateRtiltGraph generuje klasę rtilt (wiertarka z pochylonym stołem, norma sumy):
+// punkty w kwadracie squareSize x squareSize, waga = |dx| + koszt ruchu w pionie zależny od kierunku
+func GenerateRtiltGraph(g MatrixGraph, vertexCount, noEdgeValue, squareSize, upFactor, downFactor int, seed int64) error {
+	points := randomPoints(newSeededRand(seed), vertexCount, squareSize)
+	if err := fillGeneratedGraph(g, vertexCount, noEdgeValue, "rtilt", seed, func(i, j int) int {
+		return int(math.Abs(points[i].X-points[j].X)) + tiltedVerticalCost(points[i], points[j], upFactor, downFactor)
+	}); err != nil {
+		return err
+	}
+	g.SetCoordinates(points)
+	return nil
+}
+
+// GenerateStiltGraph generuje klasę stilt (wiertarka z pochylonym stołem, norma maksimum):
+// waga = max(|dx|, koszt ruchu w pionie zależny od kierunku)
+func GenerateStiltGraph(g MatrixGraph, vertexCount, noEdgeValue, squareSize, upFactor, downFactor int, seed int64) error {
+	points := randomPoints(newSeededRand(seed), vertexCount, squareSize)
+	if err := fillGeneratedGraph(g, vertexCount, noEdgeValue, "stilt", seed, func(i, j int) int {
+		dx := int(math.Abs(points[i].X - points[j].X))
+		dy := tiltedVerticalCost(points[i], points[j], upFactor, downFactor)
+		if dx > dy {
+			return dx
+		}
+		return dy
+	}); err != nil {
+		return err
+	}
+	g.SetCoordinates(points)
+	return nil
+}
+
+// GenerateCraneGraph generuje klasę crane (dźwig układający kontenery): wierzchołek to zadanie przeniesienia
+// ładunku z punktu źródłowego do docelowego odległego o co najwyżej maxJobLength w każdej osi.
+// Waga i -> j to droga pustego dźwigu z celu zadania i do źródła zadania j plus długość zadania j.
+// Współrzędne grafu to punkty źródłowe zadań.
+func GenerateCraneGraph(g MatrixGraph, vertexCount, noEdgeValue, squareSize, maxJobLength int, seed int64) error {
+	rng := newSeededRand(seed)
+	sources := randomPoints(rng, vertexCount, squareSize)
+	targets := make([]Coordinate, vertexCount)
+	clamp := func(v float64) float64 {
+		return math.Max(0, math.Min(float64(squareSize-1), v))
+	}
+	for i, source := range sources {
+		targets[i] = Coordinate{
+			X: clamp(source.X + float64(rng.Intn(2*maxJobLength+1)-maxJobLength)),
+			Y: clamp(source.Y + float64(rng.Intn(2*maxJobLength+1)-maxJobLength)),
+		}
+	}
+	if err := fillGeneratedGraph(g, vertexCount, noEdgeValue, "crane", seed, func(i, j int) int {
+		return euc2DDistance(targets[i], sources[j]) + euc2DDistance(sources[j], targets[j])
+	}); err != nil {
+		return err
+	}
+	g.SetCoordinates(sources)
+	return nil
+}
+
+// GenerateDiskGraph generuje klasę disk (szeregowanie odczytów z dysku): X to pozycja kątowa bloku
+// w zakresie [0, rotationTime), a Y numer ścieżki z zakresu [0, trackCount).
+// Przesunięcie głowicy trwa seekFactor za każdą ścieżkę, a waga i -> j to czas oczekiwania,
+// aż blok j znajdzie się pod głowicą po zakończeniu przesunięcia (z pełnymi obrotami dysku).
+func GenerateDiskGraph(g MatrixGraph, vertexCount, noEdgeValue, trackCount, rotationTime, seekFactor int, seed int64) error {
+	rng := newSeededRand(seed)
+	blocks := make([]Coordinate, vertexCount)
+	for i := range blocks {
+		blocks[i] = Coordinate{X: float64(rng.Intn(rotationTime)), Y: float64(rng.Intn(trackCount))}
+	}
+	if err := fillGeneratedGraph(g, vertexCount, noEdgeValue, "disk", seed, func(i, j int) int {
+		seek := seekFactor * int(math.Abs(blocks[i].Y-blocks[j].Y))
+		wait := ((int(blocks[j].X-blocks[i].X) % rotationTime) + rotationTime) % rotationTime
+		if wait < seek {
+			wait += ((seek - wait + rotationTime - 1) / rotationTime) * rotationTime
+		}
+		return wait
+	}); err != nil {
+		return err
+	}
+	g.SetCoordinates(blocks)
+	return nil
+}
+
+// GenerateCoinGraph generuje klasę coin (zbieranie monet z automatów telefonicznych): wierzchołki leżą
+// na skrzyżowaniach siatki gridSize x gridSize ulic jednokierunkowych o naprzemiennych kierunkach
+// (ulice brzegowe są dwukierunkowe, więc siatka jest silnie spójna).
+// Waga to długość najkrótszej drogi zgodnej z kierunkami ulic, razy blockLength.
+func GenerateCoinGraph(g MatrixGraph, vertexCount, noEdgeValue, gridSize, blockLength int, seed int64) error {
+	rng := newSeededRand(seed)
+	points := randomPoints(rng, vertexCount, gridSize)
+
+	// Dozwolone ruchy z punktu (x, y): poziomo wzdłuż ulicy y, pionowo wzdłuż ulicy x
+	neighbours := func(x, y int) [][2]int {
+		moves := make([][2]int, 0, 4)
+		twoWayRow := y == 0 || y == gridSize-1
+		twoWayColumn := x == 0 || x == gridSize-1
+		if x+1 < gridSize && (twoWayRow || y%2 == 0) {
+			moves = append(moves, [2]int{x + 1, y})
+		}
+		if x-1 >= 0 && (twoWayRow || y%2 == 1) {
+			moves = append(moves, [2]int{x - 1, y})
+		}
+		if y+1 < gridSize && (twoWayColumn || x%2 == 0) {
+			moves = append(moves, [2]int{x, y + 1})
+		}
+		if y-1 >= 0 && (twoWayColumn || x%2 == 1) {
+			moves = append(moves, [2]int{x, y - 1})
+		}
+		return moves
+	}
+
+	// BFS po siatce z każdego wierzchołka
+	distances := make([][]int, vertexCount)
+	for i, start := range points {
+		gridDistance := make([]int, gridSize*gridSize)
+		for k := range gridDistance {
+			gridDistance[k] = -1
+		}
+		sx, sy := int(start.X), int(start.Y)
+		gridDistance[sy*gridSize+sx] = 0
+		queue := [][2]int{{sx, sy}}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			for _, next := range neighbours(current[0], current[1]) {
+				if idx := next[1]*gridSize + next[0]; gridDistance[idx] == -1 {
+					gridDistance[idx] = gridDistance[current[1]*gridSize+current[0]] + 1
+					queue = append(queue, next)
+				}
+			}
+		}
+		distances[i] = make([]int, vertexCount)
+		for j, end := range points {
+			distances[i][j] = gridDistance[int(end.Y)*gridSize+int(end.X)] * blockLength
+		}
+	}
+
+	if err := fillGeneratedGraph(g, vertexCount, noEdgeValue, "coin", seed, func(i, j int) int {
+		return distances[i][j]
+	}); err != nil {
+		return err
+	}
+	g.SetCoordinates(points)
+	return nil
+}
+
+// GenerateShopGraph generuje klasę shop (szeregowanie no-wait flowshop): wierzchołek to zadanie
+// z losowymi czasami obróbki z zakresu [1, maxProcessingTime] na machineCount maszynach.
+// Waga i -> j to minimalne opóźnienie rozpoczęcia zadania j po rozpoczęciu zadania i,
+// przy którym zadanie j nigdy nie czeka między maszynami.
+func GenerateShopGraph(g MatrixGraph, vertexCount, noEdgeValue, machineCount, maxProcessingTime int, seed int64) error {
+	rng := newSeededRand(seed)
+	processing := make([][]int, vertexCount)
+	for i := range processing {
+		processing[i] = make([]int, machineCount)
+		for k := range processing[i] {
+			processing[i][k] = rng.Intn(maxProcessingTime) + 1
+		}
+	}
+	return fillGeneratedGraph(g, vertexCount, noEdgeValue, "shop", seed, func(i, j int) int {
+		delay := 0
+		sumI, sumJ := 0, 0
+		for k := 0; k < machineCount; k++ {
+			sumI += processing[i][k]
+			if d := sumI - sumJ; d > delay {
+				delay = d
+			}
+			sumJ += processing[j][k]
+		}
+		return delay
+	})
+}
+
+// GenerateSuperGraph generuje klasę super (przybliżone najkrótsze wspólne nadsłowo): wierzchołek to losowe
+// słowo długości stringLength nad alfabetem o alphabetSize literach.
+// Waga i -> j to liczba liter słowa j, które trzeba dopisać po słowie i (długość minus najdłuższe nałożenie).
+func GenerateSuperGraph(g MatrixGraph, vertexCount, noEdgeValue, stringLength, alphabetSize int, seed int64) error {
+	rng := newSeededRand(seed)
+	words := make([][]byte, vertexCount)
+	for i := range words {
+		words[i] = make([]byte, stringLength)
+		for k := range words[i] {
+			words[i][k] = byte(rng.Intn(alphabetSize))
+		}
+	}
+	return fillGeneratedGraph(g, vertexCount, noEdgeValue, "super", seed, func(i, j int) int {
+		for overlap := stringLength - 1; overlap > 0; overlap-- {
+			if string(words[i][stringLength-overlap:]) == string(words[j][:overlap]) {
+				return stringLength - overlap
+			}
+		}
+		return stringLength
+	})
+}
@@ -0,0 +1,124 @@
+package graph
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+// generatorTest opisuje wywołanie generatora z ustalonymi parametrami; generate zapisuje instancję w g
+type generatorTest struct {
+	name     string
+	generate func(g MatrixGraph, noEdgeValue int, seed int64) error
+}
+
+var dimacsGeneratorTests = []generatorTest{
+	{"amat", func(g MatrixGraph, noEdgeValue int, seed int64) error {
+		return GenerateAmatGraph(g, 6, noEdgeValue, 3, seed)
+	}},
+	{"tmat", func(g MatrixGraph, noEdgeValue int, seed int64) error {
+		return GenerateTmatGraph(g, 6, noEdgeValue, 100, seed)
+	}},
+	{"rect", func(g MatrixGraph, noEdgeValue int, seed int64) error {
+		return GenerateRectGraph(g, 6, noEdgeValue, 100, seed)
+	}},
+	{"rtilt", func(g MatrixGraph, noEdgeValue int, seed int64) error {
+		return GenerateRtiltGraph(g, 6, noEdgeValue, 100, 2, 0, seed)
+	}},
+	{"stilt", func(g MatrixGraph, noEdgeValue int, seed int64) error {
+		return GenerateStiltGraph(g, 6, noEdgeValue, 100, 2, 0, seed)
+	}},
+	{"crane", func(g MatrixGraph, noEdgeValue int, seed int64) error {
+		return GenerateCraneGraph(g, 6, noEdgeValue, 100, 10, seed)
+	}},
+	{"disk", func(g MatrixGraph, noEdgeValue int, seed int64) error {
+		return GenerateDiskGraph(g, 6, noEdgeValue, 20, 50, 2, seed)
+	}},
+	{"coin", func(g MatrixGraph, noEdgeValue int, seed int64) error {
+		return GenerateCoinGraph(g, 6, noEdgeValue, 5, 10, seed)
+	}},
+	{"shop", func(g MatrixGraph, noEdgeValue int, seed int64) error {
+		return GenerateShopGraph(g, 6, noEdgeValue, 5, 20, seed)
+	}},
+	{"super", func(g MatrixGraph, noEdgeValue int, seed int64) error {
+		return GenerateSuperGraph(g, 6, noEdgeValue, 4, 2, seed)
+	}},
+}
+
+// checkGeneratorRejectsNoEdgeValueInWeightRange sprawdza, że generator odrzuca nieujemne noEdgeValue
+// (łuki o wadze 0 stałyby się brakiem krawędzi) i nie zmienia wtedy grafu
+func checkGeneratorRejectsNoEdgeValueInWeightRange(t *testing.T, tests []generatorTest) {
+	for _, tt := range tests {
+		for _, noEdgeValue := range []int{0, 1, 100000000} {
+			t.Run(tt.name+"/noEdgeValue="+strconv.Itoa(noEdgeValue), func(t *testing.T) {
+				g := NewAdjMatrixGraph(2, -1)
+				g.AddEdge(0, 1, 5)
+				if err := tt.generate(g, noEdgeValue, 42); err == nil {
+					t.Fatal("oczekiwano błędu")
+				}
+				if g.GetVertexCount() != 2 || g.GetNoEdgeValue() != -1 || g.GetEdge(0, 1).Weight != 5 {
+					t.Error("graf został zmieniony mimo błędu")
+				}
+			})
+		}
+	}
+}
+
+// checkGeneratorOnEveryRepresentation sprawdza, że generator daje na każdej reprezentacji ten sam graf
+// bez pętli co ADJ_MATRIX, także gdy graf docelowy miał wcześniej inne noEdgeValue (jak grafy menu)
+func checkGeneratorOnEveryRepresentation(t *testing.T, tests []generatorTest) {
+	for _, tt := range tests {
+		want := NewAdjMatrixGraph(0, -1)
+		if err := tt.generate(want, -1, 42); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		for _, representation := range testRepresentations {
+			t.Run(tt.name+"/"+representation, func(t *testing.T) {
+				got := newTestGraph(t, representation, 0)
+				if err := tt.generate(got, -1, 42); err != nil {
+					t.Fatal(err)
+				}
+				assertSameWeights(t, got, want)
+				for v := 0; v < got.GetVertexCount(); v++ {
+					if got.IsAdjacent(v, v) {
+						t.Errorf("pętla w wierzchołku %d", v)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestDIMACSGeneratorsOnEveryRepresentation(t *testing.T) {
+	checkGeneratorOnEveryRepresentation(t, dimacsGeneratorTests)
+}
+
+func TestDIMACSGeneratorsRejectNonNegativeNoEdgeValue(t *testing.T) {
+	checkGeneratorRejectsNoEdgeValueInWeightRange(t, dimacsGeneratorTests)
+}
+
+func TestDIMACSGeneratorsSeeds(t *testing.T) {
+	checkGeneratorSeeds(t, dimacsGeneratorTests)
+}
+
+func TestGenerateRectGraph(t *testing.T) {
+	g := NewAdjMatrixGraph(0, -1)
+	if err := GenerateRectGraph(g, 8, -1, 50, 7); err != nil {
+		t.Fatal(err)
+	}
+	points := g.GetCoordinates()
+	if len(points) != 8 || g.GetMetadata().Name != "rect8" {
+		t.Fatalf("%d współrzędnych, nazwa %q", len(points), g.GetMetadata().Name)
+	}
+	for i := range points {
+		for j := range points {
+			if i == j {
+				continue
+			}
+			want := int(math.Abs(points[i].X-points[j].X) + math.Abs(points[i].Y-points[j].Y))
+			if w := g.GetEdge(i, j).Weight; w != want || w != g.GetEdge(j, i).Weight {
+				t.Errorf("waga %d -> %d = %d, oczekiwano %d w obu kierunkach", i, j, w, want)
+			}
+		}
+	}
+}
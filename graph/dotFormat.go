@@ -0,0 +1,130 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DOTOptions określa, jak zapisać graf w formacie DOT (Graphviz)
+type DOTOptions struct {
+	Tour           []int  // Trasa wyróżniana kolorem (np. ścieżka zwrócona przez solver); nil - bez trasy
+	HideOtherEdges bool   // Pomija krawędzie spoza trasy zamiast je wyszarzać
+	TourColor      string // Kolor łuków trasy (domyślnie "red")
+}
+
+// SaveGraphToDOTFile zapisuje graf jako digraph w formacie DOT (dla rozszerzenia .gz z kompresją gzip).
+// Łuki są opisane wagami, a wierzchołki etykietami z metadanych lub numerami.
+// Jeśli graf ma współrzędne, są one zapisywane w atrybucie pos (rysowanie: neato -n lub -Kneato).
+func SaveGraphToDOTFile(g Graph, filePath string, options DOTOptions) error {
+	file, err := createOutputFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	if err := WriteDOT(file, g, options); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// WriteDOT zapisuje graf w formacie DOT do w. Trasa z options.Tour jest rysowana jako kolorowy cykl
+// z numerem kolejnym każdego łuku, a wierzchołek startowy jest oznaczony podwójnym okręgiem.
+// Pętle (krawędzie i -> i) są pomijane. Zwraca błąd, jeśli trasa zawiera wierzchołek spoza grafu.
+func WriteDOT(w io.Writer, g Graph, options DOTOptions) error {
+	vertexCount := g.GetVertexCount()
+	tour := openTour(options.Tour)
+	for _, v := range tour {
+		if v < 0 || v >= vertexCount {
+			return fmt.Errorf("wierzchołek trasy %d poza zakresem grafu", v)
+		}
+	}
+	tourColor := options.TourColor
+	if tourColor == "" {
+		tourColor = "red"
+	}
+
+	metadata := GetGraphMetadata(g)
+	name := metadata.Name
+	if name == "" {
+		name = "graph" + strconv.Itoa(vertexCount)
+	}
+	vertexLabel := func(v int) string {
+		if len(metadata.VertexLabels) == vertexCount {
+			return metadata.VertexLabels[v]
+		}
+		return strconv.Itoa(v)
+	}
+
+	out := bufio.NewWriter(w)
+	out.WriteString("digraph " + dotQuote(name) + " {\n")
+	caption := name
+	if metadata.Comment != "" {
+		caption += "\n" + metadata.Comment
+	}
+	if len(tour) > 0 {
+		caption += "\nKoszt trasy: " + FormatPathWeight(g, append(tour, tour[0]))
+	}
+	out.WriteString("\tlabel=" + dotQuote(caption) + ";\n")
+	out.WriteString("\tnode [shape=circle];\n")
+
+	var coordinates []Coordinate
+	if cg, ok := g.(CoordinateGraph); ok && len(cg.GetCoordinates()) == vertexCount {
+		coordinates = cg.GetCoordinates()
+	}
+	for v := 0; v < vertexCount; v++ {
+		attributes := []string{"label=" + dotQuote(vertexLabel(v))}
+		if coordinates != nil {
+			attributes = append(attributes, "pos="+dotQuote(FormatWeight(coordinates[v].X)+","+FormatWeight(coordinates[v].Y)+"!"))
+		}
+		if len(tour) > 0 && v == tour[0] {
+			attributes = append(attributes, "shape=doublecircle", "color="+dotQuote(tourColor))
+		}
+		out.WriteString("\t" + strconv.Itoa(v) + " [" + strings.Join(attributes, ", ") + "];\n")
+	}
+
+	// Łuki trasy (również nieistniejące w grafie - są wtedy przerywane)
+	inTour := make(map[[2]int]bool, len(tour))
+	for k := range tour {
+		from, to := tour[k], tour[(k+1)%len(tour)]
+		inTour[[2]int{from, to}] = true
+		attributes := []string{
+			"label=" + dotQuote(strconv.Itoa(k+1)+": "+formatEdgeWeight(g, from, to)),
+			"color=" + dotQuote(tourColor),
+			"fontcolor=" + dotQuote(tourColor),
+			"penwidth=2.5",
+		}
+		if !g.IsAdjacent(from, to) {
+			attributes = append(attributes, "style=dashed")
+		}
+		out.WriteString("\t" + strconv.Itoa(from) + " -> " + strconv.Itoa(to) + " [" + strings.Join(attributes, ", ") + "];\n")
+	}
+
+	if len(tour) == 0 || !options.HideOtherEdges {
+		faded := ""
+		if len(tour) > 0 {
+			faded = ", color=gray80, fontcolor=gray60"
+		}
+		for _, edge := range g.GetAllEdges() {
+			from, to := edge.StartVertex, edge.EndVertex
+			if from == to || inTour[[2]int{from, to}] {
+				continue
+			}
+			out.WriteString("\t" + strconv.Itoa(from) + " -> " + strconv.Itoa(to) + " [label=" + dotQuote(formatEdgeWeight(g, from, to)) + faded + "];\n")
+		}
+	}
+	out.WriteString("}\n")
+
+	return out.Flush()
+}
+
+// dotQuote zwraca identyfikator DOT w cudzysłowach (znaki " i \ są poprzedzane \, a znak nowej linii zamieniany na \n)
+func dotQuote(text string) string {
+	text = strings.ReplaceAll(text, "\\", "\\\\")
+	text = strings.ReplaceAll(text, "\"", "\\\"")
+	text = strings.ReplaceAll(text, "\n", "\\n")
+	return "\"" + text + "\""
+}
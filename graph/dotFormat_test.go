@@ -0,0 +1,55 @@
+package graph
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteDOTTourCaption(t *testing.T) {
+	fill := func(g MatrixGraph) MatrixGraph {
+		fillGeneratedGraph(g, 4, -1, "test", 1, func(i, j int) int {
+			return 10*i + j + 1
+		})
+		return g
+	}
+
+	tests := []struct {
+		name    string
+		graph   func() Graph
+		tour    []int
+		caption string
+	}{
+		{"trasa dopuszczalna", func() Graph { return fill(NewAdjMatrixGraph(0, -1)) }, []int{0, 1, 2, 3, 0},
+			"Koszt trasy: 70"},
+		{"trasa bez powrotu do startu", func() Graph { return fill(NewAdjMatrixGraph(0, -1)) }, []int{0, 1, 2, 3},
+			"Koszt trasy: 70"},
+		{"brakujący łuk trasy", func() Graph {
+			g := fill(NewAdjMatrixGraph(0, -1))
+			g.RemoveEdge(2, 3)
+			return g
+		}, []int{0, 1, 2, 3, 0}, "Koszt trasy: trasa niedopuszczalna (ścieżka używa nieistniejącej krawędzi 2 -> 3)"},
+		{"brakujący łuk powrotu", func() Graph {
+			g := fill(NewAdjListGraph(0, -1))
+			g.RemoveEdge(3, 0)
+			return g
+		}, []int{0, 1, 2, 3}, "Koszt trasy: trasa niedopuszczalna (ścieżka używa nieistniejącej krawędzi 3 -> 0)"},
+		{"wagi niecałkowite", func() Graph {
+			g := fill(NewFloatMatrixGraph(0, -1)).(*FloatMatrixGraph)
+			g.AddEdgeFloat(0, 1, 1.25)
+			return g
+		}, []int{0, 1, 2, 3, 0}, "Koszt trasy: 69.25"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := WriteDOT(&out, tt.graph(), DOTOptions{Tour: tt.tour}); err != nil {
+				t.Fatalf("WriteDOT: %v", err)
+			}
+			if !strings.Contains(out.String(), tt.caption) {
+				t.Errorf("podpis nie zawiera %q:\n%s", tt.caption, out.String())
+			}
+		})
+	}
+}
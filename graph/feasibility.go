@@ -0,0 +1,124 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrPathWeightOverflow oznacza, że suma wag ścieżki nie mieści się w typie int
+var ErrPathWeightOverflow = errors.New("koszt ścieżki przekracza zakres typu int")
+
+// MissingEdgeError oznacza, że ścieżka przechodzi po krawędzi, której nie ma w grafie (waga noEdgeValue)
+type MissingEdgeError struct {
+	StartVertex int
+	EndVertex   int
+}
+
+func (e *MissingEdgeError) Error() string {
+	return fmt.Sprintf("ścieżka używa nieistniejącej krawędzi %d -> %d", e.StartVertex, e.EndVertex)
+}
+
+// SparseGraph jest implementowany przez grafy, w których brak krawędzi między parą wierzchołków jest typowy
+type SparseGraph interface {
+	Graph
+	IsSparse() bool
+}
+
+// IsSparseGraph zwraca true, jeśli graf nie gwarantuje krawędzi między każdą parą wierzchołków (np. AdjListGraph).
+// SA i TS zaczynają wtedy od trasy zachłannej zamiast losowej permutacji.
+// Grafy macierzowe nie są traktowane jako rzadkie, nawet jeśli część wag jest równa noEdgeValue.
+func IsSparseGraph(g Graph) bool {
+	sparse, ok := g.(SparseGraph)
+	return ok && sparse.IsSparse()
+}
+
+// IsCompleteGraph zwraca true, jeśli każda para różnych wierzchołków jest połączona krawędzią.
+// Pętle (krawędzie z wierzchołka do niego samego) nie mają znaczenia.
+// Krawędzie są sprawdzane przez IsAdjacent, bo licznik GetEdgeCount nie jest aktualny w każdej reprezentacji
+// (np. AdjMatrixGraph budowany przez AddEdge).
+func IsCompleteGraph(g Graph) bool {
+	vertexCount := g.GetVertexCount()
+	for i := 0; i < vertexCount; i++ {
+		for j := 0; j < vertexCount; j++ {
+			if i != j && !g.IsAdjacent(i, j) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// CalculatePathWeightChecked oblicza wagę ścieżki, sprawdzając każdą krawędź. W odróżnieniu od CalculatePathWeight
+// nie dolicza noEdgeValue za brakujące krawędzie, tylko zwraca *MissingEdgeError, a przekroczenie zakresu int
+// sygnalizuje błędem ErrPathWeightOverflow (dla float64 - nieskończonym kosztem).
+// Trasa jednowierzchołkowa [v, v] ma koszt 0.
+func CalculatePathWeightChecked[W Weight](g Graph, path []int) (W, error) {
+	if len(path) == 2 && path[0] == path[1] {
+		return 0, nil
+	}
+	weight := WeightFunction[W](g)
+	var total W
+	for i := 0; i < len(path)-1; i++ {
+		if !g.IsAdjacent(path[i], path[i+1]) {
+			return 0, &MissingEdgeError{StartVertex: path[i], EndVertex: path[i+1]}
+		}
+		w := weight(path[i], path[i+1])
+		if addOverflows(total, w) {
+			return 0, ErrPathWeightOverflow
+		}
+		total += w
+	}
+	return total, nil
+}
+
+// addOverflows sprawdza, czy a + b wykracza poza zakres typu W
+func addOverflows[W Weight](a, b W) bool {
+	var zero W
+	if _, isFloat := any(zero).(float64); isFloat {
+		return math.IsInf(float64(a+b), 0)
+	}
+	return (b > 0 && a > W(math.MaxInt)-b) || (b < 0 && a < W(math.MinInt)-b)
+}
+
+// IsFeasiblePath sprawdza, czy każda para kolejnych wierzchołków ścieżki jest połączona krawędzią
+func IsFeasiblePath(g Graph, path []int) bool {
+	for i := 0; i < len(path)-1; i++ {
+		if !g.IsAdjacent(path[i], path[i+1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// MissingEdgePenalty zwraca karę za brakującą krawędź większą niż koszt dowolnego cyklu Hamiltona w grafie
+// (suma najcięższych krawędzi wychodzących z każdego wierzchołka + 1), dzięki czemu każda trasa dopuszczalna
+// jest tańsza od trasy z brakującą krawędzią
+func MissingEdgePenalty[W Weight](g Graph) W {
+	weight := WeightFunction[W](g)
+	var penalty W = 1
+	for i := 0; i < g.GetVertexCount(); i++ {
+		var maxWeight W
+		for _, edge := range g.GetEdgesFromVertex(i) {
+			if w := weight(i, edge.EndVertex); w > maxWeight {
+				maxWeight = w
+			}
+		}
+		penalty += maxWeight
+	}
+	return penalty
+}
+
+// PenalizedPathWeight zwraca wagę ścieżki, w której każda brakująca krawędź kosztuje penalty
+func PenalizedPathWeight[W Weight](g Graph, path []int, penalty W) W {
+	weight := WeightFunction[W](g)
+	var total W
+	for i := 0; i < len(path)-1; i++ {
+		if g.IsAdjacent(path[i], path[i+1]) {
+			total += weight(path[i], path[i+1])
+		} else {
+			total += penalty
+		}
+	}
+	return total
+}
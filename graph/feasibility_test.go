@@ -0,0 +1,50 @@
+package graph
+
+import "testing"
+
+func TestIsCompleteGraph(t *testing.T) {
+	complete := func(g Graph, vertexCount int) Graph {
+		for i := 0; i < vertexCount; i++ {
+			for j := 0; j < vertexCount; j++ {
+				if i != j {
+					g.AddEdge(i, j, i+j)
+				}
+			}
+		}
+		return g
+	}
+	without := func(g Graph, startVertex, endVertex int) Graph {
+		g.RemoveEdge(startVertex, endVertex)
+		return g
+	}
+	withLoop := func(g Graph, vertex int) Graph {
+		g.AddEdge(vertex, vertex, 1)
+		return g
+	}
+
+	tests := []struct {
+		name  string
+		graph Graph
+		want  bool
+	}{
+		{"macierz budowana przez AddEdge", complete(NewAdjMatrixGraph(4, -1), 4), true},
+		{"macierz bez jednej krawędzi", without(complete(NewAdjMatrixGraph(4, -1), 4), 1, 2), false},
+		{"macierz z pętlą", withLoop(complete(NewAdjMatrixGraph(4, -1), 4), 2), true},
+		{"macierz z pętlą bez jednej krawędzi", withLoop(without(complete(NewAdjMatrixGraph(4, -1), 4), 3, 0), 0), false},
+		{"płaska macierz", complete(NewFlatMatrixGraph(4, -1, CellWidth32), 4), true},
+		{"listy sąsiedztwa", complete(NewAdjListGraph(4, -1), 4), true},
+		{"listy sąsiedztwa bez jednej krawędzi", without(complete(NewAdjListGraph(4, -1), 4), 0, 3), false},
+		{"wagi zmiennoprzecinkowe", complete(NewFloatMatrixGraph(4, -1), 4), true},
+		{"wagi zmiennoprzecinkowe bez jednej krawędzi", without(complete(NewFloatMatrixGraph(4, -1), 4), 2, 1), false},
+		{"graf pusty", NewAdjMatrixGraph(0, -1), true},
+		{"jeden wierzchołek", NewAdjListGraph(1, -1), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsCompleteGraph(tt.graph); got != tt.want {
+				t.Errorf("IsCompleteGraph = %t, oczekiwano %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,170 +3,212 @@ package graph
 import (
 	"bufio"
 	"errors"
-	"os"
+	"fmt"
+	"io"
 	"strconv"
 	"strings"
 )
 
-// LoadGraphFromFile wczytuje graf z pliku tekstowego o podanej ścieżce.
-// Jeśli isATSP = true, próbuje wczytać plik w formacie ATSP (EXPLICIT, FULL_MATRIX).
-// W przeciwnym wypadku oczekuje formatu:
-// - w pierwszej linii: liczba wierzchołków
-// - w kolejnych liniach: macierz n x n
+// maxLineLength to maksymalna długość pojedynczej linii w plikach tekstowych
+const maxLineLength = 256 * 1024 * 1024
+
+// LoadGraphFromFile wczytuje graf z pliku o podanej ścieżce i zwraca rozpoznany format pliku.
+// Pliki skompresowane gzip lub bzip2 (np. .atsp.gz, .tsp.bz2) są rozpakowywane automatycznie.
+// Format jest wykrywany automatycznie na podstawie początku pliku (patrz DetectFileFormat):
+// - FileFormatMatrix: w pierwszej linii liczba wierzchołków, w kolejnych liniach macierz n x n,
+// - FileFormatTSPLIBExplicit: TSPLIB z EDGE_WEIGHT_TYPE: EXPLICIT i dowolnym formatem EDGE_WEIGHT_FORMAT
+// (pełna macierz lub warianty trójkątne i kolumnowe),
+// - FileFormatTSPLIBCoordinates: TSPLIB z EDGE_WEIGHT_TYPE: EUC_2D, CEIL_2D, ATT, GEO, MAN_2D lub MAX_2D
+// i sekcją NODE_COORD_SECTION; macierz jest wyliczana według reguł zaokrąglania TSPLIB,
+// a współrzędne zostają zapamiętane w grafie,
+// - FileFormatJSON: obiekt JSON z macierzą lub listą krawędzi (patrz SaveGraphToJSONFile);
+// wartość noEdgeValue z pliku zastępuje wartość grafu,
+// - FileFormatCSVEdgeList: lista krawędzi from,to,weight (patrz LoadGraphFromCSVEdgeList z domyślnymi opcjami),
+// - FileFormatBinary: zwarty format binarny z sumą kontrolną (patrz SaveGraphToBinaryFile).
+// Na przekątną formatów, które jej nie zawierają, wpisywana jest wartość noEdgeValue grafu.
+// Wagi niecałkowite (formaty macierzowy, JSON i CSV) są akceptowane tylko przez FloatMatrixGraph.
 // Funkcja poprawnie interpretuje wielokrotne spacje jako separator.
-func LoadGraphFromFile(filePath string, graph *AdjMatrixGraph, isATSP ...bool) error {
-	file, err := os.Open(filePath)
+func LoadGraphFromFile(filePath string, graph MatrixGraph) (string, error) {
+	file, err := openInputFile(filePath)
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
+	reader := bufio.NewReaderSize(file, formatSniffSize)
+	format, err := detectFileFormat(reader)
+	if err != nil {
+		return "", err
+	}
 
-	if len(isATSP) > 0 && isATSP[0] {
-		// Wczytywanie formatu ATSP
-		var dimension int
-		inMatrixSection := false
+	switch format {
+	case FileFormatMatrix:
+		err = loadMatrixFormat(newLineScanner(reader), graph)
+	case FileFormatTSPLIBExplicit, FileFormatTSPLIBCoordinates:
+		err = loadTSPLIBFormat(newLineScanner(reader), graph)
+	case FileFormatJSON:
+		err = loadJSONFormat(reader, graph)
+	case FileFormatCSVEdgeList:
+		err = loadCSVEdgeListFormat(reader, graph, CSVEdgeListOptions{})
+	case FileFormatBinary:
+		err = loadBinaryFormat(reader, graph)
+	}
+	if err != nil {
+		return "", err
+	}
+	return format, nil
+}
 
-		// Tablica do której wczytamy wszystkie liczby z sekcji EDGE_WEIGHT_SECTION
-		var allNumbers []int
+// loadTSPLIBFormat wczytuje graf w formacie TSPLIB (jawna macierz wag lub współrzędne)
+func loadTSPLIBFormat(scanner *bufio.Scanner, graph MatrixGraph) error {
+	instance, err := parseTSPLIB(scanner)
+	if err != nil {
+		return err
+	}
+	matrix, err := instance.buildMatrix(graph.GetNoEdgeValue())
+	if err != nil {
+		return err
+	}
+	return storeMatrix(graph, matrix, instance.coordinates, Metadata{Name: instance.name, Comment: instance.comment, EdgeWeightType: instance.edgeWeightType})
+}
 
-		for scanner.Scan() {
-			line := strings.TrimSpace(scanner.Text())
+// loadMatrixFormat wczytuje graf w formacie: liczba wierzchołków w pierwszej linii, a następnie macierz n x n
+func loadMatrixFormat(scanner *bufio.Scanner, graph MatrixGraph) error {
+	if !scanner.Scan() {
+		return errors.New("plik jest pusty lub nieprawidłowy")
+	}
+	firstLine := strings.TrimSpace(scanner.Text())
+	vertexCount, err := strconv.Atoi(firstLine)
+	if err != nil {
+		return errors.New("błąd podczas odczytu liczby wierzchołków")
+	}
 
-			// Szukamy wymiaru grafu w linii zawierającej "DIMENSION:"
-			if strings.HasPrefix(line, "DIMENSION:") {
-				parts := strings.Split(line, ":")
-				if len(parts) == 2 {
-					dimStr := strings.TrimSpace(parts[1])
-					dimension, err = strconv.Atoi(dimStr)
-					if err != nil {
-						return errors.New("błąd przy konwersji wymiaru z pliku ATSP")
-					}
-				}
-			}
+	// Wiersze są zapisywane bezpośrednio w grafie docelowym, bez pośredniej macierzy [][]int
+	graph.resetMatrix(vertexCount)
+	graph.SetCoordinates(nil)
+	graph.SetMetadata(Metadata{})
 
-			// Szukamy sekcji z wagami
-			if strings.HasPrefix(line, "EDGE_WEIGHT_SECTION") {
-				inMatrixSection = true
+	row := 0
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		values := strings.Fields(line)
+		if len(values) != vertexCount {
+			return errors.New("niewłaściwa liczba elementów w wierszu macierzy")
+		}
+		for j, val := range values {
+			num, err := strconv.Atoi(val)
+			if err != nil {
+				// Wagi niecałkowite są dopuszczalne tylko w grafach zmiennoprzecinkowych (FloatMatrixGraph)
+				if err := setFloatWeight(graph, row, j, val); err != nil {
+					return err
+				}
 				continue
 			}
-
-			// Jeśli jesteśmy w sekcji z macierzą
-			if inMatrixSection {
-				// Wczytujemy wszystkie liczby do jednej tablicy
-				if line != "" {
-					values := strings.Fields(line)
-					for _, val := range values {
-						num, err := strconv.Atoi(val)
-						if err != nil {
-							return errors.New("błąd konwersji wartości w macierzy ATSP")
-						}
-						allNumbers = append(allNumbers, num)
-					}
-				}
-
-				// Jeżeli mamy już wystarczającą liczbę elementów do zapełnienia macierzy dimension x dimension, możemy przerwać
-				if dimension > 0 && len(allNumbers) >= dimension*dimension {
-					break
-				}
+			if !graph.setWeight(row, j, num) {
+				return weightOverflowError(num)
 			}
 		}
-
-		if dimension == 0 {
-			return errors.New("nie znaleziono wymiaru w pliku ATSP")
-		}
-		if len(allNumbers) < dimension*dimension {
-			return errors.New("zbyt mało danych w sekcji EDGE_WEIGHT_SECTION aby uzupełnić macierz")
-		}
-
-		// Inicjalizacja grafu
-		graph.vertexCount = dimension
-		graph.adjMatrix = make([][]int, dimension)
-		for i := 0; i < dimension; i++ {
-			graph.adjMatrix[i] = make([]int, dimension)
+		row++
+		if row == vertexCount {
+			break
 		}
+	}
 
-		// Uzupełnienie macierzy z tablicy allNumbers
-		idx := 0
-		for i := 0; i < dimension; i++ {
-			for j := 0; j < dimension; j++ {
-				graph.adjMatrix[i][j] = allNumbers[idx]
-				idx++
-			}
-		}
+	if row != vertexCount {
+		return errors.New("niewłaściwa liczba wierszy w macierzy sąsiedztwa")
+	}
+	return nil
+}
 
-		return nil
+// storeMatrix zapisuje wczytaną macierz w grafie docelowym razem ze współrzędnymi i metadanymi
+func storeMatrix(graph MatrixGraph, matrix [][]int, coordinates []Coordinate, metadata Metadata) error {
+	if adjGraph, ok := graph.(*AdjMatrixGraph); ok {
+		// AdjMatrixGraph może przejąć macierz bez kopiowania
+		adjGraph.setMatrix(matrix)
 	} else {
-		// Wczytywanie standardowego formatu
-		if !scanner.Scan() {
-			return errors.New("plik jest pusty lub nieprawidłowy")
-		}
-		firstLine := strings.TrimSpace(scanner.Text())
-		vertexCount, err := strconv.Atoi(firstLine)
-		if err != nil {
-			return errors.New("błąd podczas odczytu liczby wierzchołków")
+		graph.resetMatrix(len(matrix))
+		for i, row := range matrix {
+			for j, weight := range row {
+				if !graph.setWeight(i, j, weight) {
+					return weightOverflowError(weight)
+				}
+			}
 		}
+	}
+	graph.SetCoordinates(coordinates)
+	graph.SetMetadata(metadata)
+	return nil
+}
 
-		graph.vertexCount = vertexCount
-		graph.adjMatrix = make([][]int, vertexCount)
+// storeEdges zapisuje w grafie docelowym listę krawędzi; pozostałe pary wierzchołków otrzymują wagę noEdgeValue.
+// Dla AdjListGraph zapisywane są tylko podane krawędzie, bez przechodzenia przez wszystkie n² par.
+func storeEdges(graph MatrixGraph, vertexCount int, edges []Edge, coordinates []Coordinate, metadata Metadata) error {
+	graph.resetMatrix(vertexCount)
+	if !IsSparseGraph(graph) {
+		noEdgeValue := graph.GetNoEdgeValue()
 		for i := 0; i < vertexCount; i++ {
-			graph.adjMatrix[i] = make([]int, vertexCount)
-		}
-
-		row := 0
-		for scanner.Scan() {
-			line := strings.TrimSpace(scanner.Text())
-			if line == "" {
-				continue
-			}
-			values := strings.Fields(line)
-			if len(values) != vertexCount {
-				return errors.New("niewłaściwa liczba elementów w wierszu macierzy")
-			}
-			for j, val := range values {
-				num, err := strconv.Atoi(val)
-				if err != nil {
-					return errors.New("błąd przy konwersji wartości macierzy do liczby całkowitej")
+			for j := 0; j < vertexCount; j++ {
+				if !graph.setWeight(i, j, noEdgeValue) {
+					return weightOverflowError(noEdgeValue)
 				}
-				graph.adjMatrix[row][j] = num
-			}
-			row++
-			if row == vertexCount {
-				break
 			}
 		}
-
-		if row != vertexCount {
-			return errors.New("niewłaściwa liczba wierszy w macierzy sąsiedztwa")
+	}
+	for _, edge := range edges {
+		if !graph.setWeight(edge.StartVertex, edge.EndVertex, edge.Weight) {
+			return weightOverflowError(edge.Weight)
 		}
-		return nil
 	}
+	graph.SetCoordinates(coordinates)
+	graph.SetMetadata(metadata)
+	return nil
+}
+
+// setFloatWeight zapisuje wagę niecałkowitą, jeśli graf docelowy obsługuje wagi zmiennoprzecinkowe
+func setFloatWeight(graph MatrixGraph, startVertex, endVertex int, value string) error {
+	setter, ok := graph.(floatWeightSetter)
+	if !ok {
+		return fmt.Errorf("waga %s nie jest liczbą całkowitą, a graf nie obsługuje wag zmiennoprzecinkowych", value)
+	}
+	weight, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("nieprawidłowa waga: %s", value)
+	}
+	setter.setWeightFloat(startVertex, endVertex, weight)
+	return nil
+}
+
+// weightOverflowError zwraca błąd dla wagi, której nie da się zapisać w komórce macierzy grafu
+func weightOverflowError(weight int) error {
+	return fmt.Errorf("waga %d nie mieści się w komórce macierzy grafu", weight)
 }
 
 // SaveGraphToFile zapisuje graf do pliku w formacie:
 // Pierwsza linia: liczba wierzchołków
 // Kolejne linie: macierz n x n
 // Jeśli useTabsAsSeparator = true, wartości oddzielone tabulatorami, w przeciwnym wypadku spacjami.
+// Wagi grafów FloatGraph są zapisywane bez zaokrąglania.
+// Jeśli ścieżka kończy się na .gz, plik jest kompresowany algorytmem gzip.
 func SaveGraphToFile(g Graph, filePath string, useTabsAsSeparator ...bool) error {
 	separator := " "
 	if len(useTabsAsSeparator) > 0 && useTabsAsSeparator[0] {
 		separator = "\t"
 	}
 
-	file, err := os.Create(filePath)
+	file, err := createOutputFile(filePath)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
 
-	var out strings.Builder
+	out := bufio.NewWriter(file)
 	out.WriteString(strconv.Itoa(g.GetVertexCount()) + "\n")
 
 	for i := 0; i < g.GetVertexCount(); i++ {
 		for j := 0; j < g.GetVertexCount(); j++ {
-			edge := g.GetEdge(i, j)
-			out.WriteString(strconv.Itoa(edge.Weight))
+			out.WriteString(formatEdgeWeight(g, i, j))
 			if j < g.GetVertexCount()-1 {
 				out.WriteString(separator)
 			}
@@ -174,10 +216,39 @@ func SaveGraphToFile(g Graph, filePath string, useTabsAsSeparator ...bool) error
 		out.WriteString("\n")
 	}
 
-	_, err = file.WriteString(out.String())
+	if err := out.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// SaveGraphToTSPLIBFile zapisuje graf do pliku w formacie TSPLIB (EDGE_WEIGHT_TYPE: EXPLICIT).
+// Plik zawiera nagłówek NAME, TYPE, COMMENT, DIMENSION, EDGE_WEIGHT_TYPE, EDGE_WEIGHT_FORMAT
+// oraz sekcję EDGE_WEIGHT_SECTION i może zostać ponownie wczytany przez LoadGraphFromFile.
+// Sekcja EDGE_WEIGHT_SECTION zawiera liczby całkowite, dlatego graf FloatGraph z wagą niecałkowitą
+// jest odrzucany (przed utworzeniem pliku) zamiast zapisywać zaokrąglone wagi.
+// Jeśli ścieżka kończy się na .gz, plik jest kompresowany algorytmem gzip.
+func SaveGraphToTSPLIBFile(g Graph, filePath string, options TSPLIBSaveOptions) error {
+	if err := checkIntegerWeights(g); err != nil {
+		return err
+	}
+	file, err := createOutputFile(filePath)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	if err := writeTSPLIB(file, g, options); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// newLineScanner tworzy skaner linii z buforem wystarczającym dla wierszy bardzo dużych macierzy
+// (domyślny limit bufio.Scanner to 64 KB na linię, czyli mniej niż wiersz macierzy 16000 x 16000)
+func newLineScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 1024*1024), maxLineLength)
+	return scanner
 }
@@ -0,0 +1,93 @@
+package graph
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadGraphFromFileOnEveryRepresentation(t *testing.T) {
+	sources := []struct {
+		name        string
+		graph       func() Graph
+		integerOnly bool // Wagi całkowite; pozostałe wczytuje tylko FloatMatrixGraph
+		fitsInt32   bool
+	}{
+		{"pełny asymetryczny", func() Graph {
+			g := NewAdjMatrixGraph(0, -1)
+			fillGeneratedGraph(g, 5, -1, "pelny", 1, func(i, j int) int { return 10*i + j })
+			return g
+		}, true, true},
+		{"rzadki z wagą 0 i int64", func() Graph {
+			g := NewAdjMatrixGraph(4, -1)
+			g.AddEdge(0, 1, 5)
+			g.AddEdge(1, 2, 0)
+			g.AddEdge(2, 3, 7)
+			g.AddEdge(3, 0, 1<<35)
+			return g
+		}, true, false},
+		{"wagi niecałkowite", func() Graph {
+			g := NewFloatMatrixGraph(3, -1)
+			g.AddEdgeFloat(0, 1, 2.5)
+			g.AddEdgeFloat(1, 2, 0.25)
+			g.AddEdgeFloat(2, 0, 4)
+			return g
+		}, false, true},
+	}
+
+	savers := []struct {
+		name        string
+		fileName    string
+		save        func(g Graph, filePath string) error
+		integerOnly bool // Zapis odrzuca wagi niecałkowite
+		keepsNoEdge bool // Plik przechowuje noEdgeValue; w przeciwnym razie graf docelowy musi mieć noEdgeValue źródła
+	}{
+		{"macierz", "graf.txt", func(g Graph, filePath string) error { return SaveGraphToFile(g, filePath) }, false, false},
+		{"macierz gzip", "graf.txt.gz", func(g Graph, filePath string) error { return SaveGraphToFile(g, filePath, true) }, false, false},
+		{"TSPLIB", "graf.atsp", func(g Graph, filePath string) error {
+			return SaveGraphToTSPLIBFile(g, filePath, TSPLIBSaveOptions{Name: "graf"})
+		}, true, false},
+		{"JSON", "graf.json", func(g Graph, filePath string) error { return SaveGraphToJSONFile(g, filePath, false) }, false, true},
+		{"JSON rzadki", "graf.json", func(g Graph, filePath string) error { return SaveGraphToJSONFile(g, filePath, true) }, false, true},
+		{"CSV", "graf.csv", SaveGraphToCSVEdgeList, false, false},
+	}
+
+	for _, source := range sources {
+		for _, saver := range savers {
+			if saver.integerOnly && !source.integerOnly {
+				continue
+			}
+			for _, representation := range testRepresentations {
+				t.Run(source.name+"/"+saver.name+"/"+representation, func(t *testing.T) {
+					want := source.graph()
+					filePath := filepath.Join(t.TempDir(), saver.fileName)
+					if err := saver.save(want, filePath); err != nil {
+						t.Fatalf("zapis: %v", err)
+					}
+
+					noEdgeValue := want.GetNoEdgeValue()
+					if saver.keepsNoEdge {
+						noEdgeValue = 0
+					}
+					got := newTestGraph(t, representation, noEdgeValue)
+					_, err := LoadGraphFromFile(filePath, got)
+					if !source.integerOnly && representation != RepresentationFloat {
+						if err == nil {
+							t.Fatal("oczekiwano błędu dla wag niecałkowitych")
+						}
+						return
+					}
+					if representation == RepresentationFlat32 && !source.fitsInt32 {
+						if err == nil {
+							t.Fatal("oczekiwano błędu dla wagi spoza zakresu int32")
+						}
+						return
+					}
+					if err != nil {
+						t.Fatalf("LoadGraphFromFile: %v", err)
+					}
+					assertSameWeights(t, got, want)
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package graph
+
+import (
+	"math"
+	"strconv"
+)
+
+// FloatTolerance to względna tolerancja porównywania kosztów zmiennoprzecinkowych w solverach dokładnych
+const FloatTolerance = 1e-9
+
+// Weight to typ wagi krawędzi i kosztu trasy obsługiwany przez solvery: int lub float64
+type Weight interface {
+	~int | ~float64
+}
+
+// FloatGraph jest implementowany przez grafy z wagami zmiennoprzecinkowymi (np. FloatMatrixGraph).
+// Metody interfejsu Graph zwracają dla nich wagi zaokrąglone do najbliższej liczby całkowitej.
+type FloatGraph interface {
+	Graph
+	GetEdgeWeightFloat(startVertex, endVertex int) float64
+	CalculatePathWeightFloat(path []int) float64
+}
+
+// floatWeightSetter jest implementowany przez grafy, do których loadery mogą zapisywać wagi niecałkowite
+type floatWeightSetter interface {
+	setWeightFloat(startVertex, endVertex int, weight float64)
+}
+
+// IsFloatGraph zwraca true, jeśli graf przechowuje wagi zmiennoprzecinkowe
+func IsFloatGraph(g Graph) bool {
+	_, ok := g.(FloatGraph)
+	return ok
+}
+
+// EdgeWeightFloat zwraca wagę krawędzi jako float64; dla grafów całkowitoliczbowych jest to GetEdge(...).Weight
+func EdgeWeightFloat(g Graph, startVertex, endVertex int) float64 {
+	if fg, ok := g.(FloatGraph); ok {
+		return fg.GetEdgeWeightFloat(startVertex, endVertex)
+	}
+	return float64(g.GetEdge(startVertex, endVertex).Weight)
+}
+
+// PathWeightFloat zwraca wagę ścieżki jako float64
+func PathWeightFloat(g Graph, path []int) float64 {
+	if fg, ok := g.(FloatGraph); ok {
+		return fg.CalculatePathWeightFloat(path)
+	}
+	return float64(g.CalculatePathWeight(path))
+}
+
+// WeightFunction zwraca funkcję odczytu wag krawędzi w typie W
+func WeightFunction[W Weight](g Graph) func(startVertex, endVertex int) W {
+	var zero W
+	if _, isFloat := any(zero).(float64); isFloat {
+		return func(startVertex, endVertex int) W {
+			return W(EdgeWeightFloat(g, startVertex, endVertex))
+		}
+	}
+	return func(startVertex, endVertex int) W {
+		return W(g.GetEdge(startVertex, endVertex).Weight)
+	}
+}
+
+// WeightMatrix odczytuje jednorazowo wszystkie wagi w typie W oraz informację o istnieniu krawędzi (IsAdjacent).
+// Istnienia krawędzi nie można wyznaczać z wagi: w FloatGraph waga zaokrąglona do noEdgeValue (np. 0.4 przy noEdgeValue 0)
+// należy do istniejącej krawędzi. Przeznaczone dla solverów dokładnych, które wielokrotnie odczytują te same krawędzie.
+func WeightMatrix[W Weight](g Graph) ([][]W, [][]bool) {
+	weight := WeightFunction[W](g)
+	vertexCount := g.GetVertexCount()
+	weights := make([][]W, vertexCount)
+	adjacent := make([][]bool, vertexCount)
+	for i := 0; i < vertexCount; i++ {
+		weights[i] = make([]W, vertexCount)
+		adjacent[i] = make([]bool, vertexCount)
+		for j := 0; j < vertexCount; j++ {
+			weights[i][j] = weight(i, j)
+			adjacent[i][j] = g.IsAdjacent(i, j)
+		}
+	}
+	return weights, adjacent
+}
+
+// PathWeight zwraca wagę ścieżki w typie W (CalculatePathWeight albo PathWeightFloat)
+func PathWeight[W Weight](g Graph, path []int) W {
+	var zero W
+	if _, isFloat := any(zero).(float64); isFloat {
+		return W(PathWeightFloat(g, path))
+	}
+	return W(g.CalculatePathWeight(path))
+}
+
+// MaxWeight zwraca wartość większą od kosztu każdej trasy: math.MaxInt dla int i +Inf dla float64
+func MaxWeight[W Weight]() W {
+	var zero W
+	if _, isFloat := any(zero).(float64); isFloat {
+		inf := math.Inf(1)
+		return W(inf)
+	}
+	maxInt := math.MaxInt
+	return W(maxInt)
+}
+
+// LessWeight zwraca true, jeśli koszt a jest mniejszy od b. Dla float64 różnica musi przekraczać
+// tolerancję FloatTolerance (względną dla dużych kosztów), dzięki czemu błędy zaokrągleń nie zmieniają wyniku.
+func LessWeight[W Weight](a, b W) bool {
+	var zero W
+	if _, isFloat := any(zero).(float64); isFloat {
+		fa, fb := float64(a), float64(b)
+		if math.IsInf(fb, 1) {
+			return !math.IsInf(fa, 1)
+		}
+		return fa < fb-FloatTolerance*math.Max(1, math.Abs(fb))
+	}
+	return a < b
+}
+
+// FormatWeight zamienia koszt na tekst bez zbędnych zer (int jako liczba całkowita)
+func FormatWeight[W Weight](w W) string {
+	var zero W
+	if _, isFloat := any(zero).(float64); isFloat {
+		return strconv.FormatFloat(float64(w), 'f', -1, 64)
+	}
+	return strconv.Itoa(int(w))
+}
+
+// formatEdgeWeight zwraca wagę krawędzi jako tekst; dla grafów FloatGraph bez zaokrąglania
+func formatEdgeWeight(g Graph, startVertex, endVertex int) string {
+	if fg, ok := g.(FloatGraph); ok && fg.IsAdjacent(startVertex, endVertex) {
+		return strconv.FormatFloat(fg.GetEdgeWeightFloat(startVertex, endVertex), 'f', -1, 64)
+	}
+	return strconv.Itoa(g.GetEdge(startVertex, endVertex).Weight)
+}
+
+// FormatPathWeight zwraca koszt ścieżki jako tekst (CalculatePathWeightChecked, dla grafów FloatGraph bez zaokrąglania).
+// Dla ścieżki z brakującą krawędzią lub przepełnieniem zwraca opis błędu zamiast kosztu z doliczonym noEdgeValue.
+func FormatPathWeight(g Graph, path []int) string {
+	var cost string
+	var err error
+	if IsFloatGraph(g) {
+		var floatCost float64
+		floatCost, err = CalculatePathWeightChecked[float64](g, path)
+		cost = FormatWeight(floatCost)
+	} else {
+		var intCost int
+		intCost, err = CalculatePathWeightChecked[int](g, path)
+		cost = FormatWeight(intCost)
+	}
+	if err != nil {
+		return "trasa niedopuszczalna (" + err.Error() + ")"
+	}
+	return cost
+}
@@ -0,0 +1,79 @@
+package graph
+
+import "testing"
+
+func TestWeightMatrixAdjacency(t *testing.T) {
+	tests := []struct {
+		name  string
+		graph func() Graph
+	}{
+		{"waga zaokrąglana do noEdgeValue", func() Graph {
+			g := NewFloatMatrixGraph(3, 0)
+			g.AddEdgeFloat(0, 1, 0.4)
+			g.AddEdgeFloat(1, 2, -0.3)
+			g.AddEdgeFloat(2, 0, 5)
+			return g
+		}},
+		{"waga 0 przy noEdgeValue -1", func() Graph {
+			g := NewAdjListGraph(3, -1)
+			g.AddEdge(0, 1, 0)
+			g.AddEdge(1, 0, 7)
+			return g
+		}},
+		{"macierz sąsiedztwa", func() Graph {
+			g := NewAdjMatrixGraph(3, 100)
+			g.AddEdge(0, 2, 1)
+			g.AddEdge(2, 1, 99)
+			return g
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := tt.graph()
+			floatWeights, floatAdjacent := WeightMatrix[float64](g)
+			_, intAdjacent := WeightMatrix[int](g)
+			for i := 0; i < g.GetVertexCount(); i++ {
+				for j := 0; j < g.GetVertexCount(); j++ {
+					if floatAdjacent[i][j] != g.IsAdjacent(i, j) || intAdjacent[i][j] != g.IsAdjacent(i, j) {
+						t.Errorf("krawędź %d -> %d: adjacent = %t/%t, IsAdjacent = %t", i, j, floatAdjacent[i][j], intAdjacent[i][j], g.IsAdjacent(i, j))
+					}
+					if g.IsAdjacent(i, j) && floatWeights[i][j] != EdgeWeightFloat(g, i, j) {
+						t.Errorf("krawędź %d -> %d: waga %v, oczekiwano %v", i, j, floatWeights[i][j], EdgeWeightFloat(g, i, j))
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestFormatPathWeight(t *testing.T) {
+	intGraph := NewAdjMatrixGraph(3, 1000)
+	intGraph.AddEdge(0, 1, 4)
+	intGraph.AddEdge(1, 2, 5)
+	intGraph.AddEdge(2, 0, 6)
+	floatGraph := NewFloatMatrixGraph(3, 0)
+	floatGraph.AddEdgeFloat(0, 1, 0.25)
+	floatGraph.AddEdgeFloat(1, 2, 1.5)
+	floatGraph.AddEdgeFloat(2, 0, 2)
+
+	tests := []struct {
+		name  string
+		graph Graph
+		path  []int
+		want  string
+	}{
+		{"trasa dopuszczalna", intGraph, []int{0, 1, 2, 0}, "15"},
+		{"brakująca krawędź", intGraph, []int{0, 2, 1, 0}, "trasa niedopuszczalna (ścieżka używa nieistniejącej krawędzi 0 -> 2)"},
+		{"wagi zmiennoprzecinkowe", floatGraph, []int{0, 1, 2, 0}, "3.75"},
+		{"brakująca krawędź w grafie zmiennoprzecinkowym", floatGraph, []int{1, 0}, "trasa niedopuszczalna (ścieżka używa nieistniejącej krawędzi 1 -> 0)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatPathWeight(tt.graph, tt.path); got != tt.want {
+				t.Errorf("FormatPathWeight = %q, oczekiwano %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package graph
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Formaty plików z grafem rozpoznawane przez LoadGraphFromFile
+const (
+	FileFormatMatrix            = "MATRIX"             // Liczba wierzchołków, a następnie macierz n x n
+	FileFormatTSPLIBExplicit    = "TSPLIB_EXPLICIT"    // TSPLIB z jawną macierzą wag (EDGE_WEIGHT_SECTION)
+	FileFormatTSPLIBCoordinates = "TSPLIB_COORDINATES" // TSPLIB ze współrzędnymi (NODE_COORD_SECTION)
+	FileFormatJSON              = "JSON"               // Obiekt JSON z macierzą lub listą krawędzi
+	FileFormatCSVEdgeList       = "CSV_EDGE_LIST"      // Lista krawędzi from,to,weight
+	FileFormatBinary            = "BINARY"             // Zwarty format binarny (patrz SaveGraphToBinaryFile)
+)
+
+// formatSniffSize to liczba bajtów z początku pliku analizowana przy wykrywaniu formatu
+const formatSniffSize = 64 * 1024
+
+// DetectFileFormat rozpoznaje format pliku z grafem bez wczytywania całej zawartości.
+// Pliki skompresowane gzip lub bzip2 są analizowane po rozpakowaniu.
+func DetectFileFormat(filePath string) (string, error) {
+	file, err := openInputFile(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	return detectFileFormat(bufio.NewReaderSize(file, formatSniffSize))
+}
+
+// detectFileFormat podgląda początek strumienia (bez jego konsumowania) i na tej podstawie wybiera format
+func detectFileFormat(reader *bufio.Reader) (string, error) {
+	header, err := reader.Peek(formatSniffSize)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return "", err
+	}
+
+	if bytes.HasPrefix(header, binaryFormatMagic) {
+		return FileFormatBinary, nil
+	}
+
+	lines := strings.Split(string(header), "\n")
+	// Ostatnia linia mogła zostać ucięta na granicy podglądu
+	if len(header) == formatSniffSize && len(lines) > 1 {
+		lines = lines[:len(lines)-1]
+	}
+
+	firstLine := ""
+	for _, line := range lines {
+		if line = strings.TrimSpace(line); line != "" {
+			firstLine = line
+			break
+		}
+	}
+	if firstLine == "" {
+		return "", errors.New("plik jest pusty lub nieprawidłowy")
+	}
+
+	if strings.HasPrefix(firstLine, "{") {
+		return FileFormatJSON, nil
+	}
+	if strings.ContainsAny(firstLine, ",;") && !strings.Contains(firstLine, ":") {
+		return FileFormatCSVEdgeList, nil
+	}
+	if isTSPLIBKeywordLine(firstLine) {
+		return detectTSPLIBFormat(lines)
+	}
+	if _, err := strconv.Atoi(firstLine); err == nil {
+		return FileFormatMatrix, nil
+	}
+	return "", errors.New("nie rozpoznano formatu pliku")
+}
+
+// detectTSPLIBFormat rozróżnia warianty TSPLIB na podstawie nagłówka
+func detectTSPLIBFormat(lines []string) (string, error) {
+	isTSPLIB := false
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || !isTSPLIBKeywordLine(line) {
+			continue
+		}
+		key, value := splitTSPLIBHeaderLine(line)
+		switch key {
+		case "NAME", "DIMENSION", "COMMENT", "EDGE_WEIGHT_FORMAT":
+			isTSPLIB = true
+		case "TYPE":
+			if strings.ToUpper(value) == "TOUR" {
+				return "", errors.New("plik zawiera trasę (TYPE: TOUR), a nie graf")
+			}
+			isTSPLIB = true
+		case "EDGE_WEIGHT_TYPE":
+			if strings.ToUpper(value) == EdgeWeightTypeExplicit {
+				return FileFormatTSPLIBExplicit, nil
+			}
+			return FileFormatTSPLIBCoordinates, nil
+		case "EDGE_WEIGHT_SECTION":
+			return FileFormatTSPLIBExplicit, nil
+		case "NODE_COORD_SECTION":
+			return FileFormatTSPLIBCoordinates, nil
+		}
+	}
+	if !isTSPLIB {
+		return "", errors.New("nie rozpoznano formatu pliku")
+	}
+	// Bez EDGE_WEIGHT_TYPE parser TSPLIB zakłada jawną macierz wag
+	return FileFormatTSPLIBExplicit, nil
+}
@@ -0,0 +1,114 @@
+package graph
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+)
+
+// Generatory instancji geometrycznych. Punkty są zapisywane jako współrzędne grafu, a wagi to odległości
+// euklidesowe zaokrąglone jak w TSPLIB (EUC_2D). Tak jak generatory DIMACS, każdy generator nadpisuje graf g,
+// wpisuje noEdgeValue na przekątną i zapisuje nazwę klasy oraz ziarno w metadanych, a ujemne noEdgeValue
+// jest wymagane z tego samego powodu (punkty o zerowej odległości dają łuki o wadze 0).
+
+// Nazwy klas instancji geometrycznych (prefiks nazwy w metadanych)
+const (
+	InstanceClassUniform   = "uniform"
+	InstanceClassClustered = "clustered"
+	InstanceClassGrid      = "grid"
+	InstanceClassRoad      = "road"
+)
+
+// fillPointGraph wypełnia graf wagami między punktami i zapisuje punkty jako współrzędne
+func fillPointGraph(g MatrixGraph, points []Coordinate, noEdgeValue int, name, description string, seed int64, weight func(i, j int) int) error {
+	if err := fillGeneratedGraph(g, len(points), noEdgeValue, name, seed, weight); err != nil {
+		return err
+	}
+	g.SetCoordinates(points)
+	g.SetMetadata(Metadata{Name: name + strconv.Itoa(len(points)), Comment: seedComment(description, seed), Seed: seed})
+	return nil
+}
+
+// uniformPoints losuje punkty o współrzędnych rzeczywistych z kwadratu [0, squareSize) x [0, squareSize)
+func uniformPoints(rng *rand.Rand, count int, squareSize float64) []Coordinate {
+	points := make([]Coordinate, count)
+	for i := range points {
+		points[i] = Coordinate{X: rng.Float64() * squareSize, Y: rng.Float64() * squareSize}
+	}
+	return points
+}
+
+// GenerateUniformPointsGraph generuje instancję symetryczną z punktów rozłożonych jednostajnie w kwadracie squareSize x squareSize
+func GenerateUniformPointsGraph(g MatrixGraph, vertexCount, noEdgeValue, squareSize int, seed int64) error {
+	points := uniformPoints(newSeededRand(seed), vertexCount, float64(squareSize))
+	return fillPointGraph(g, points, noEdgeValue, InstanceClassUniform, "Punkty jednostajne w kwadracie", seed, func(i, j int) int {
+		return euc2DDistance(points[i], points[j])
+	})
+}
+
+// GenerateClusteredPointsGraph generuje instancję symetryczną z punktów skupionych wokół clusterCount środków
+// rozłożonych jednostajnie w kwadracie. Punkty mają rozkład normalny wokół środka z odchyleniem standardowym
+// sigma = spread * squareSize i są przycinane do kwadratu.
+func GenerateClusteredPointsGraph(g MatrixGraph, vertexCount, noEdgeValue, squareSize, clusterCount int, spread float64, seed int64) error {
+	rng := newSeededRand(seed)
+	size := float64(squareSize)
+	centers := uniformPoints(rng, max(clusterCount, 1), size)
+	sigma := spread * size
+	clamp := func(v float64) float64 {
+		return math.Max(0, math.Min(size, v))
+	}
+	points := make([]Coordinate, vertexCount)
+	for i := range points {
+		center := centers[rng.Intn(len(centers))]
+		points[i] = Coordinate{X: clamp(center.X + rng.NormFloat64()*sigma), Y: clamp(center.Y + rng.NormFloat64()*sigma)}
+	}
+	return fillPointGraph(g, points, noEdgeValue, InstanceClassClustered, "Skupiska gaussowskie ("+strconv.Itoa(len(centers))+")", seed, func(i, j int) int {
+		return euc2DDistance(points[i], points[j])
+	})
+}
+
+// GenerateGridJitterGraph generuje instancję symetryczną z punktów w węzłach regularnej siatki pokrywającej kwadrat,
+// przesuniętych losowo o co najwyżej jitter * rozstaw siatki w każdej osi (jitter = 0 daje dokładną siatkę)
+func GenerateGridJitterGraph(g MatrixGraph, vertexCount, noEdgeValue, squareSize int, jitter float64, seed int64) error {
+	rng := newSeededRand(seed)
+	side := int(math.Ceil(math.Sqrt(float64(vertexCount))))
+	spacing := float64(squareSize) / float64(max(side, 1))
+	points := make([]Coordinate, vertexCount)
+	for i := range points {
+		row, column := i/side, i%side
+		points[i] = Coordinate{
+			X: (float64(column)+0.5)*spacing + (2*rng.Float64()-1)*jitter*spacing,
+			Y: (float64(row)+0.5)*spacing + (2*rng.Float64()-1)*jitter*spacing,
+		}
+	}
+	return fillPointGraph(g, points, noEdgeValue, InstanceClassGrid, "Siatka z zaburzeniem", seed, func(i, j int) int {
+		return euc2DDistance(points[i], points[j])
+	})
+}
+
+// GenerateRoadLikeGraph generuje instancję asymetryczną przypominającą sieć drogową: odległość euklidesowa
+// między punktami jednostajnymi jest mnożona przez 1 + noise * (składowa kierunkowa + losowa składowa łuku).
+// Składowa kierunkowa zależy od kąta łuku względem losowego kierunku "pod wiatr" (droga w jedną stronę jest
+// systematycznie dłuższa niż w drugą), a losowa jest niezależna dla i -> j oraz j -> i (objazdy, ulice jednokierunkowe).
+func GenerateRoadLikeGraph(g MatrixGraph, vertexCount, noEdgeValue, squareSize int, noise float64, seed int64) error {
+	rng := newSeededRand(seed)
+	points := uniformPoints(rng, vertexCount, float64(squareSize))
+	headwind := rng.Float64() * 2 * math.Pi
+	noiseSeed := uint64(rng.Int63())
+	return fillPointGraph(g, points, noEdgeValue, InstanceClassRoad, "Asymetryczna instancja drogowa", seed, func(i, j int) int {
+		dx, dy := points[j].X-points[i].X, points[j].Y-points[i].Y
+		directional := (1 + math.Cos(math.Atan2(dy, dx)-headwind)) / 2
+		factor := 1 + noise*(directional+arcNoise(noiseSeed, i*vertexCount+j))/2
+		return int(math.Round(math.Hypot(dx, dy) * factor))
+	})
+}
+
+// arcNoise zwraca deterministyczną liczbę z [0, 1) dla łuku o podanym indeksie (mieszanie splitmix64),
+// aby nie przechowywać n² losowych wartości
+func arcNoise(seed uint64, arc int) float64 {
+	z := seed + uint64(arc+1)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	z ^= z >> 31
+	return float64(z>>11) / (1 << 53)
+}
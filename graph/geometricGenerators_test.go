@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"math"
+	"testing"
+)
+
+var geometricGeneratorTests = []generatorTest{
+	{InstanceClassUniform, func(g MatrixGraph, noEdgeValue int, seed int64) error {
+		return GenerateUniformPointsGraph(g, 9, noEdgeValue, 100, seed)
+	}},
+	{InstanceClassClustered, func(g MatrixGraph, noEdgeValue int, seed int64) error {
+		return GenerateClusteredPointsGraph(g, 9, noEdgeValue, 100, 2, 0.05, seed)
+	}},
+	{InstanceClassGrid, func(g MatrixGraph, noEdgeValue int, seed int64) error {
+		return GenerateGridJitterGraph(g, 9, noEdgeValue, 90, 0.2, seed)
+	}},
+	{InstanceClassRoad, func(g MatrixGraph, noEdgeValue int, seed int64) error {
+		return GenerateRoadLikeGraph(g, 9, noEdgeValue, 100, 0.3, seed)
+	}},
+}
+
+func TestGeometricGeneratorsOnEveryRepresentation(t *testing.T) {
+	checkGeneratorOnEveryRepresentation(t, geometricGeneratorTests)
+}
+
+func TestGeometricGeneratorsRejectNonNegativeNoEdgeValue(t *testing.T) {
+	checkGeneratorRejectsNoEdgeValueInWeightRange(t, geometricGeneratorTests)
+}
+
+func TestGeometricGeneratorsSeeds(t *testing.T) {
+	checkGeneratorSeeds(t, geometricGeneratorTests)
+}
+
+func TestGeometricGeneratorsWeights(t *testing.T) {
+	const noise = 0.3
+	tests := []struct {
+		generatorTest
+		symmetric bool
+	}{
+		{geometricGeneratorTests[0], true},
+		{geometricGeneratorTests[1], true},
+		{geometricGeneratorTests[2], true},
+		{geometricGeneratorTests[3], false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewAdjMatrixGraph(0, -1)
+			if err := tt.generate(g, -1, 3); err != nil {
+				t.Fatal(err)
+			}
+			points := g.GetCoordinates()
+			if len(points) != 9 || g.GetMetadata().Name != tt.name+"9" {
+				t.Fatalf("%d współrzędnych, nazwa %q", len(points), g.GetMetadata().Name)
+			}
+
+			asymmetric := false
+			for i := range points {
+				for j := range points {
+					if i == j {
+						continue
+					}
+					weight, distance := g.GetEdge(i, j).Weight, euc2DDistance(points[i], points[j])
+					if g.GetEdge(j, i).Weight != weight {
+						asymmetric = true
+					}
+					if tt.symmetric && weight != distance {
+						t.Errorf("waga %d -> %d = %d, oczekiwano odległości EUC_2D %d", i, j, weight, distance)
+					}
+					// Zaburzenie drogowe tylko wydłuża odległość, co najwyżej o czynnik 1 + noise
+					exact := math.Hypot(points[i].X-points[j].X, points[i].Y-points[j].Y)
+					if !tt.symmetric && (float64(weight) < math.Round(exact) || float64(weight) > math.Round(exact*(1+noise))) {
+						t.Errorf("waga %d -> %d = %d poza zakresem [%v, %v]", i, j, weight, math.Round(exact), math.Round(exact*(1+noise)))
+					}
+				}
+			}
+			if asymmetric == tt.symmetric {
+				t.Errorf("asymetryczna: %t, oczekiwano %t", asymmetric, !tt.symmetric)
+			}
+		})
+	}
+}
+
+func TestGenerateGridJitterGraphWithoutJitter(t *testing.T) {
+	g := NewAdjMatrixGraph(0, -1)
+	if err := GenerateGridJitterGraph(g, 4, -1, 20, 0, 1); err != nil {
+		t.Fatal(err)
+	}
+	want := []Coordinate{{5, 5}, {15, 5}, {5, 15}, {15, 15}}
+	for i, point := range g.GetCoordinates() {
+		if point != want[i] {
+			t.Errorf("punkt %d = %v, oczekiwano %v", i, point, want[i])
+		}
+	}
+	if w := g.GetEdge(0, 3).Weight; w != 14 {
+		t.Errorf("waga przekątnej 0 -> 3 = %d, oczekiwano 14", w)
+	}
+}
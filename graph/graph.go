@@ -1,5 +1,10 @@
 package graph
 
+import (
+	"errors"
+	"fmt"
+)
+
 type Graph interface {
 	GetNoEdgeValue() int
 	SetNoEdgeValue(int)
@@ -19,3 +24,47 @@ type Graph interface {
 	GetHamiltonianPathRandom(startVertex int) []int
 	ToString() string
 }
+
+// MatrixGraph to graf przechowujący pełną macierz wag, do którego loadery i generatory mogą zapisywać dane.
+// Implementują go AdjMatrixGraph, FlatMatrixGraph, AdjListGraph oraz FloatMatrixGraph, więc każda funkcja wczytująca lub generująca graf
+// może pracować na dowolnej z tych reprezentacji.
+type MatrixGraph interface {
+	Graph
+	GetMetadata() Metadata
+	SetMetadata(metadata Metadata)
+	GetCoordinates() []Coordinate
+	SetCoordinates(coordinates []Coordinate)
+	// resetMatrix przydziela macierz vertexCount x vertexCount (o nieokreślonej zawartości)
+	resetMatrix(vertexCount int)
+	// setWeight ustawia wagę bez zmiany licznika krawędzi; zwraca false, jeśli waga nie mieści się w komórce macierzy
+	setWeight(startVertex, endVertex, weight int) bool
+}
+
+// Reprezentacje pamięciowe grafu opartego na macierzy wag
+const (
+	RepresentationAdjMatrix = "ADJ_MATRIX" // AdjMatrixGraph: [][]int, osobny wiersz na stercie
+	RepresentationFlat32    = "FLAT_32"    // FlatMatrixGraph z komórkami int32
+	RepresentationFlat64    = "FLAT_64"    // FlatMatrixGraph z komórkami int64
+	RepresentationAdjList   = "ADJ_LIST"   // AdjListGraph: listy sąsiedztwa dla grafów rzadkich
+	RepresentationFloat     = "FLOAT"      // FloatMatrixGraph: wagi zmiennoprzecinkowe float64
+	RepresentationImplicit  = "IMPLICIT"   // ImplicitCoordinateGraph: wagi wyliczane ze współrzędnych (patrz LoadImplicitGraphFromTSPLIBFile)
+)
+
+// NewMatrixGraph tworzy pusty graf o podanej reprezentacji, gotowy do wczytania lub wygenerowania danych
+func NewMatrixGraph(representation string, noEdgeValue int) (MatrixGraph, error) {
+	switch representation {
+	case RepresentationAdjMatrix:
+		return NewAdjMatrixGraph(0, noEdgeValue), nil
+	case RepresentationFlat32:
+		return NewFlatMatrixGraph(0, noEdgeValue, CellWidth32), nil
+	case RepresentationFlat64:
+		return NewFlatMatrixGraph(0, noEdgeValue, CellWidth64), nil
+	case RepresentationAdjList:
+		return NewAdjListGraph(0, noEdgeValue), nil
+	case RepresentationFloat:
+		return NewFloatMatrixGraph(0, noEdgeValue), nil
+	case RepresentationImplicit:
+		return nil, errors.New("graf wyliczany ze współrzędnych nie przechowuje macierzy wag, należy użyć LoadImplicitGraphFromTSPLIBFile")
+	}
+	return nil, fmt.Errorf("nieznana reprezentacja grafu: %s", representation)
+}
@@ -0,0 +1,221 @@
+package graph
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AdjListGraph przechowuje dla każdego wierzchołka listy krawędzi wychodzących i wchodzących,
+// więc zużywa pamięć proporcjonalną do liczby krawędzi, a nie n². Przeznaczony dla grafów rzadkich
+// (np. instancji pochodzących z sieci drogowych), w których większość par wierzchołków nie jest połączona.
+// Krawędź o wadze równej noEdgeValue nie jest przechowywana - zapisanie takiej wagi usuwa krawędź.
+type AdjListGraph struct {
+	outEdges    [][]Edge // Krawędzie wychodzące, posortowane po EndVertex
+	inEdges     [][]Edge // Krawędzie wchodzące, posortowane po StartVertex
+	edgeCount   int
+	noEdgeValue int
+	coordinates []Coordinate
+	metadata    Metadata
+}
+
+func NewAdjListGraph(vertexCount, noEdgeValue int) *AdjListGraph {
+	newGraph := new(AdjListGraph)
+	newGraph.noEdgeValue = noEdgeValue
+	newGraph.resetMatrix(vertexCount)
+	return newGraph
+}
+
+// resetMatrix usuwa wszystkie krawędzie i ustawia liczbę wierzchołków
+func (a *AdjListGraph) resetMatrix(vertexCount int) {
+	a.outEdges = make([][]Edge, vertexCount)
+	a.inEdges = make([][]Edge, vertexCount)
+	a.edgeCount = 0
+}
+
+// setWeight dodaje lub aktualizuje krawędź; waga równa noEdgeValue usuwa krawędź
+func (a *AdjListGraph) setWeight(startVertex, endVertex, weight int) bool {
+	if weight == a.noEdgeValue {
+		a.RemoveEdge(startVertex, endVertex)
+		return true
+	}
+	edge := Edge{StartVertex: startVertex, EndVertex: endVertex, Weight: weight}
+	var inserted bool
+	a.outEdges[startVertex], inserted = upsertEdge(a.outEdges[startVertex], edge, edgeEndVertex)
+	a.inEdges[endVertex], _ = upsertEdge(a.inEdges[endVertex], edge, edgeStartVertex)
+	if inserted {
+		a.edgeCount++
+	}
+	return true
+}
+
+// IsSparse oznacza graf, w którym brak krawędzi między parą wierzchołków jest typowy (patrz IsSparseGraph)
+func (a *AdjListGraph) IsSparse() bool {
+	return true
+}
+
+// GetDegree zwraca liczbę krawędzi wychodzących z wierzchołka
+func (a *AdjListGraph) GetDegree(vertex int) int {
+	return len(a.outEdges[vertex])
+}
+
+func (a *AdjListGraph) GetMetadata() Metadata {
+	return a.metadata
+}
+
+func (a *AdjListGraph) SetMetadata(metadata Metadata) {
+	a.metadata = metadata
+}
+
+func (a *AdjListGraph) GetCoordinates() []Coordinate {
+	return a.coordinates
+}
+
+func (a *AdjListGraph) SetCoordinates(coordinates []Coordinate) {
+	a.coordinates = coordinates
+}
+
+func (a *AdjListGraph) GetNoEdgeValue() int {
+	return a.noEdgeValue
+}
+
+// SetNoEdgeValue zmienia wartość zwracaną dla brakujących krawędzi; zapisane krawędzie pozostają bez zmian
+func (a *AdjListGraph) SetNoEdgeValue(noEdgeValue int) {
+	a.noEdgeValue = noEdgeValue
+}
+
+func (a *AdjListGraph) GetVertexCount() int {
+	return len(a.outEdges)
+}
+
+func (a *AdjListGraph) GetEdgeCount() int {
+	return a.edgeCount
+}
+
+func (a *AdjListGraph) GetAllEdges() []Edge {
+	edges := make([]Edge, 0, a.edgeCount)
+	for _, out := range a.outEdges {
+		edges = append(edges, out...)
+	}
+	return edges
+}
+
+func (a *AdjListGraph) GetEdgesFromVertex(startVertex int) []Edge {
+	return append([]Edge(nil), a.outEdges[startVertex]...)
+}
+
+func (a *AdjListGraph) GetEdgesToVertex(endVertex int) []Edge {
+	return append([]Edge(nil), a.inEdges[endVertex]...)
+}
+
+func (a *AdjListGraph) GetEdge(startVertex, endVertex int) Edge {
+	out := a.outEdges[startVertex]
+	if idx, found := findEdge(out, endVertex, edgeEndVertex); found {
+		return out[idx]
+	}
+	return Edge{StartVertex: startVertex, EndVertex: endVertex, Weight: a.noEdgeValue}
+}
+
+func (a *AdjListGraph) GetMinEdgeFromWeight(vertex int) int {
+	minEdge := math.MaxInt
+	for _, edge := range a.outEdges[vertex] {
+		if edge.Weight < minEdge {
+			minEdge = edge.Weight
+		}
+	}
+	return minEdge
+}
+
+func (a *AdjListGraph) AddEdge(startVertex, endVertex, weight int) {
+	a.setWeight(startVertex, endVertex, weight)
+}
+
+func (a *AdjListGraph) RemoveEdge(startVertex, endVertex int) {
+	var removed bool
+	a.outEdges[startVertex], removed = removeEdge(a.outEdges[startVertex], endVertex, edgeEndVertex)
+	a.inEdges[endVertex], _ = removeEdge(a.inEdges[endVertex], startVertex, edgeStartVertex)
+	if removed {
+		a.edgeCount--
+	}
+}
+
+func (a *AdjListGraph) IsAdjacent(startVertex, endVertex int) bool {
+	_, found := findEdge(a.outEdges[startVertex], endVertex, edgeEndVertex)
+	return found
+}
+
+func (a *AdjListGraph) CalculatePathWeight(path []int) int {
+	weight := 0
+	for i := 0; i < len(path)-1; i++ {
+		weight += a.GetEdge(path[i], path[i+1]).Weight
+	}
+	return weight
+}
+
+func (a *AdjListGraph) PathWithWeightsToString(path []int) string {
+	return pathWithWeightsToString(a, path)
+}
+
+// GetHamiltonianPathGreedy zwraca nil, jeśli metoda najbliższego sąsiada utknie
+// lub z ostatniego wierzchołka nie ma krawędzi powrotnej do startVertex
+func (a *AdjListGraph) GetHamiltonianPathGreedy(startVertex int) []int {
+	path := hamiltonianPathGreedy(a, startVertex)
+	if path == nil || (len(path) > 2 && !a.IsAdjacent(path[len(path)-2], startVertex)) {
+		return nil
+	}
+	return path
+}
+
+func (a *AdjListGraph) GetHamiltonianPathRandom(startVertex int) []int {
+	return hamiltonianPathRandom(a, startVertex)
+}
+
+// ToString zwraca listy sąsiedztwa w postaci "v0: v1(5) v3(7)"
+func (a *AdjListGraph) ToString() string {
+	var out strings.Builder
+	for i, edges := range a.outEdges {
+		out.WriteString("v" + strconv.Itoa(i) + ":")
+		for _, edge := range edges {
+			out.WriteString(" v" + strconv.Itoa(edge.EndVertex) + "(" + strconv.Itoa(edge.Weight) + ")")
+		}
+		out.WriteString("\n")
+	}
+	return out.String()
+}
+
+func edgeEndVertex(e Edge) int {
+	return e.EndVertex
+}
+
+func edgeStartVertex(e Edge) int {
+	return e.StartVertex
+}
+
+// findEdge wyszukuje binarnie krawędź o danym wierzchołku w liście posortowanej według key
+func findEdge(edges []Edge, vertex int, key func(Edge) int) (int, bool) {
+	idx := sort.Search(len(edges), func(i int) bool { return key(edges[i]) >= vertex })
+	return idx, idx < len(edges) && key(edges[idx]) == vertex
+}
+
+// upsertEdge wstawia krawędź z zachowaniem porządku lub aktualizuje wagę istniejącej; zwraca true przy wstawieniu
+func upsertEdge(edges []Edge, edge Edge, key func(Edge) int) ([]Edge, bool) {
+	idx, found := findEdge(edges, key(edge), key)
+	if found {
+		edges[idx] = edge
+		return edges, false
+	}
+	edges = append(edges, Edge{})
+	copy(edges[idx+1:], edges[idx:])
+	edges[idx] = edge
+	return edges, true
+}
+
+// removeEdge usuwa krawędź o danym wierzchołku; zwraca true, jeśli krawędź istniała
+func removeEdge(edges []Edge, vertex int, key func(Edge) int) ([]Edge, bool) {
+	idx, found := findEdge(edges, vertex, key)
+	if !found {
+		return edges, false
+	}
+	return append(edges[:idx], edges[idx+1:]...), true
+}
@@ -0,0 +1,81 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+// edgeOperation modyfikuje graf tak samo niezależnie od reprezentacji
+type edgeOperation func(g Graph)
+
+func addEdgeOperation(startVertex, endVertex, weight int) edgeOperation {
+	return func(g Graph) { g.AddEdge(startVertex, endVertex, weight) }
+}
+
+func removeEdgeOperation(startVertex, endVertex int) edgeOperation {
+	return func(g Graph) { g.RemoveEdge(startVertex, endVertex) }
+}
+
+// sameEdges porównuje listy krawędzi, traktując nil i pustą listę jako równe
+func sameEdges(a, b []Edge) bool {
+	return len(a) == len(b) && (len(a) == 0 || reflect.DeepEqual(a, b))
+}
+
+func TestAdjListGraphMatchesAdjMatrix(t *testing.T) {
+	const noEdgeValue = -1
+	tests := []struct {
+		name       string
+		operations []edgeOperation
+		edgeCount  int
+	}{
+		{"pusty", nil, 0},
+		{"krawędzie w odwrotnej kolejności", []edgeOperation{addEdgeOperation(0, 4, 3), addEdgeOperation(0, 2, 8), addEdgeOperation(0, 1, 5), addEdgeOperation(3, 0, 1)}, 4},
+		{"aktualizacja wagi", []edgeOperation{addEdgeOperation(1, 2, 5), addEdgeOperation(1, 2, 9)}, 1},
+		{"waga 0", []edgeOperation{addEdgeOperation(2, 3, 0), addEdgeOperation(3, 2, 0)}, 2},
+		{"usunięcie", []edgeOperation{addEdgeOperation(1, 2, 5), addEdgeOperation(1, 3, 6), addEdgeOperation(4, 2, 7), removeEdgeOperation(1, 2)}, 2},
+		{"usunięcie nieistniejącej krawędzi", []edgeOperation{addEdgeOperation(1, 2, 5), removeEdgeOperation(2, 1)}, 1},
+		{"AddEdge z wagą noEdgeValue usuwa krawędź", []edgeOperation{addEdgeOperation(1, 2, 5), addEdgeOperation(1, 2, noEdgeValue)}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewAdjListGraph(5, noEdgeValue)
+			want := NewAdjMatrixGraph(5, noEdgeValue)
+			for _, operation := range tt.operations {
+				operation(got)
+				operation(want)
+			}
+
+			assertSameWeights(t, got, want)
+			if got.GetEdgeCount() != tt.edgeCount || len(got.GetAllEdges()) != tt.edgeCount {
+				t.Errorf("liczba krawędzi %d (GetAllEdges %d), oczekiwano %d", got.GetEdgeCount(), len(got.GetAllEdges()), tt.edgeCount)
+			}
+			for v := 0; v < got.GetVertexCount(); v++ {
+				if from := got.GetEdgesFromVertex(v); !sameEdges(from, want.GetEdgesFromVertex(v)) || got.GetDegree(v) != len(from) {
+					t.Errorf("krawędzie z %d: %v (stopień %d), oczekiwano %v", v, from, got.GetDegree(v), want.GetEdgesFromVertex(v))
+				}
+				if to := got.GetEdgesToVertex(v); !sameEdges(to, want.GetEdgesToVertex(v)) {
+					t.Errorf("krawędzie do %d: %v, oczekiwano %v", v, to, want.GetEdgesToVertex(v))
+				}
+				if got.GetDegree(v) > 0 && got.GetMinEdgeFromWeight(v) != want.GetMinEdgeFromWeight(v) {
+					t.Errorf("najmniejsza waga z %d: %d, oczekiwano %d", v, got.GetMinEdgeFromWeight(v), want.GetMinEdgeFromWeight(v))
+				}
+			}
+		})
+	}
+}
+
+// Zmiana noEdgeValue nie usuwa zapisanych krawędzi, także tych o wadze równej nowej wartości
+func TestAdjListGraphSetNoEdgeValue(t *testing.T) {
+	g := NewAdjListGraph(3, -1)
+	g.AddEdge(0, 1, 0)
+	g.AddEdge(1, 2, 4)
+	g.SetNoEdgeValue(0)
+
+	if !g.IsAdjacent(0, 1) || g.GetEdge(0, 1).Weight != 0 || g.GetEdgeCount() != 2 {
+		t.Errorf("krawędź 0 -> 1: IsAdjacent = %t, waga %d, liczba krawędzi %d", g.IsAdjacent(0, 1), g.GetEdge(0, 1).Weight, g.GetEdgeCount())
+	}
+	if w := g.GetEdge(2, 0).Weight; g.IsAdjacent(2, 0) || w != 0 {
+		t.Errorf("brakująca krawędź 2 -> 0: waga %d, oczekiwano noEdgeValue 0", w)
+	}
+}
@@ -12,6 +12,8 @@ type AdjMatrixGraph struct {
 	vertexCount int
 	edgeCount   int
 	noEdgeValue int
+	coordinates []Coordinate // Współrzędne wierzchołków, jeśli graf powstał z instancji opartej na punktach
+	metadata    Metadata
 }
 
 func NewAdjMatrixGraph(vertexCount, noEdgeValue int) *AdjMatrixGraph {
@@ -29,6 +31,28 @@ func NewAdjMatrixGraph(vertexCount, noEdgeValue int) *AdjMatrixGraph {
 	return newGraph
 }
 
+// setMatrix podmienia macierz sąsiedztwa (np. po wczytaniu z pliku) i unieważnia licznik krawędzi
+func (a *AdjMatrixGraph) setMatrix(matrix [][]int) {
+	a.adjMatrix = matrix
+	a.vertexCount = len(matrix)
+	a.edgeCount = -1
+}
+
+// resetMatrix przydziela nową macierz vertexCount x vertexCount wypełnioną zerami
+func (a *AdjMatrixGraph) resetMatrix(vertexCount int) {
+	matrix := make([][]int, vertexCount)
+	for i := 0; i < vertexCount; i++ {
+		matrix[i] = make([]int, vertexCount)
+	}
+	a.setMatrix(matrix)
+}
+
+// setWeight ustawia wagę krawędzi bez zmiany licznika krawędzi
+func (a *AdjMatrixGraph) setWeight(startVertex, endVertex, weight int) bool {
+	a.adjMatrix[startVertex][endVertex] = weight
+	return true
+}
+
 func (a *AdjMatrixGraph) GetNoEdgeValue() int {
 	return a.noEdgeValue
 }
@@ -37,6 +61,26 @@ func (a *AdjMatrixGraph) SetNoEdgeValue(noEdgeValue int) {
 	a.noEdgeValue = noEdgeValue
 }
 
+// GetMetadata zwraca metadane instancji (nazwa, komentarz)
+func (a *AdjMatrixGraph) GetMetadata() Metadata {
+	return a.metadata
+}
+
+// SetMetadata ustawia metadane instancji
+func (a *AdjMatrixGraph) SetMetadata(metadata Metadata) {
+	a.metadata = metadata
+}
+
+// GetCoordinates zwraca współrzędne wierzchołków lub nil, jeśli graf ich nie posiada
+func (a *AdjMatrixGraph) GetCoordinates() []Coordinate {
+	return a.coordinates
+}
+
+// SetCoordinates ustawia współrzędne wierzchołków (bez przeliczania wag)
+func (a *AdjMatrixGraph) SetCoordinates(coordinates []Coordinate) {
+	a.coordinates = coordinates
+}
+
 func (a *AdjMatrixGraph) GetVertexCount() int {
 	return len(a.adjMatrix)
 }
@@ -133,6 +177,7 @@ func (a *AdjMatrixGraph) PathWithWeightsToString(path []int) string {
 	return out.String()
 }
 
+// GetHamiltonianPathGreedy zwraca nil, jeśli z bieżącego wierzchołka nie ma krawędzi do nieodwiedzonego wierzchołka
 func (a *AdjMatrixGraph) GetHamiltonianPathGreedy(startVertex int) []int {
 	visited := make([]bool, a.GetVertexCount())
 	path := make([]int, 0)
@@ -149,6 +194,9 @@ func (a *AdjMatrixGraph) GetHamiltonianPathGreedy(startVertex int) []int {
 				nextVertex = edge.EndVertex
 			}
 		}
+		if nextVertex == -1 {
+			return nil
+		}
 		path = append(path, nextVertex)
 		currentVertex = nextVertex
 		visited[currentVertex] = true
@@ -0,0 +1,290 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+)
+
+// Powyżej tej liczby wierzchołków nierówność trójkąta jest sprawdzana na losowej próbie trójek wierzchołków
+const triangleExactVertexLimit = 150
+
+// Liczba losowanych trójek wierzchołków przy sprawdzaniu nierówności trójkąta w dużych grafach
+const triangleSampleCount = 1000000
+
+// GraphAnalysis to raport z analizy instancji: symetria, nierówność trójkąta, rozkład wag, gęstość,
+// silna spójność i proste warunki konieczne istnienia cyklu Hamiltona.
+// Pętle (krawędzie i -> i) są pomijane we wszystkich statystykach.
+type GraphAnalysis struct {
+	VertexCount int
+	EdgeCount   int // Liczba krawędzi bez pętli
+	NoEdgeValue int
+	Density     float64 // EdgeCount / (n * (n - 1))
+	Symmetric   bool
+
+	// Rozkład wag istniejących krawędzi (waga różna od noEdgeValue)
+	MinWeight    float64
+	MaxWeight    float64
+	MeanWeight   float64
+	StdDevWeight float64
+
+	// Nierówność trójkąta w(i, j) <= w(i, k) + w(k, j) sprawdzana dla trójek, w których istnieją wszystkie trzy krawędzie
+	TriangleChecks     int
+	TriangleViolations int
+	TriangleSampled    bool // true, jeśli sprawdzono tylko losową próbę trójek
+
+	StronglyConnected bool
+	NoOutgoingEdges   []int // Wierzchołki bez krawędzi wychodzących
+	NoIncomingEdges   []int // Wierzchołki bez krawędzi wchodzących
+
+	HamiltonianPossible bool     // false, jeśli któryś z warunków koniecznych nie jest spełniony
+	HamiltonianIssues   []string // Opisy niespełnionych warunków koniecznych
+}
+
+// TriangleViolationRatio zwraca odsetek sprawdzonych trójek łamiących nierówność trójkąta
+func (a GraphAnalysis) TriangleViolationRatio() float64 {
+	if a.TriangleChecks == 0 {
+		return 0
+	}
+	return float64(a.TriangleViolations) / float64(a.TriangleChecks)
+}
+
+// AnalyzeGraph wyznacza raport GraphAnalysis dla grafu.
+// Wagi krawędzi są odczytywane jako float64, więc dla grafów FloatGraph statystyki uwzględniają dokładne wagi.
+func AnalyzeGraph(g Graph) GraphAnalysis {
+	vertexCount := g.GetVertexCount()
+	analysis := GraphAnalysis{
+		VertexCount: vertexCount,
+		NoEdgeValue: g.GetNoEdgeValue(),
+		Symmetric:   isSymmetric(g),
+	}
+
+	analyzeWeights(g, &analysis)
+	if vertexCount > 1 {
+		analysis.Density = float64(analysis.EdgeCount) / float64(vertexCount*(vertexCount-1))
+	}
+	analyzeTriangleInequality(g, &analysis)
+	analyzeConnectivity(g, &analysis)
+	return analysis
+}
+
+// analyzeWeights liczy krawędzie oraz minimum, maksimum, średnią i odchylenie standardowe wag (bez pętli)
+func analyzeWeights(g Graph, analysis *GraphAnalysis) {
+	sum, sumOfSquares := 0.0, 0.0
+	for _, edge := range g.GetAllEdges() {
+		if edge.StartVertex == edge.EndVertex {
+			continue
+		}
+		weight := EdgeWeightFloat(g, edge.StartVertex, edge.EndVertex)
+		if analysis.EdgeCount == 0 || weight < analysis.MinWeight {
+			analysis.MinWeight = weight
+		}
+		if analysis.EdgeCount == 0 || weight > analysis.MaxWeight {
+			analysis.MaxWeight = weight
+		}
+		analysis.EdgeCount++
+		sum += weight
+		sumOfSquares += weight * weight
+	}
+	if analysis.EdgeCount == 0 {
+		return
+	}
+	count := float64(analysis.EdgeCount)
+	analysis.MeanWeight = sum / count
+	variance := sumOfSquares/count - analysis.MeanWeight*analysis.MeanWeight
+	analysis.StdDevWeight = math.Sqrt(math.Max(variance, 0))
+}
+
+// analyzeTriangleInequality zlicza trójki (i, k, j), dla których droga przez k jest krótsza niż krawędź i -> j.
+// Dla małych grafów sprawdzane są wszystkie trójki (krawędzie wchodzące i wychodzące z k),
+// dla dużych - losowa próba triangleSampleCount trójek.
+func analyzeTriangleInequality(g Graph, analysis *GraphAnalysis) {
+	vertexCount := g.GetVertexCount()
+	check := func(i, k, j int) {
+		if !g.IsAdjacent(i, k) || !g.IsAdjacent(k, j) || !g.IsAdjacent(i, j) {
+			return
+		}
+		direct := EdgeWeightFloat(g, i, j)
+		detour := EdgeWeightFloat(g, i, k) + EdgeWeightFloat(g, k, j)
+		analysis.TriangleChecks++
+		if direct-detour > FloatTolerance*math.Max(1, math.Abs(direct)) {
+			analysis.TriangleViolations++
+		}
+	}
+
+	if vertexCount < 3 {
+		return
+	}
+	if vertexCount <= triangleExactVertexLimit {
+		for k := 0; k < vertexCount; k++ {
+			incoming := g.GetEdgesToVertex(k)
+			outgoing := g.GetEdgesFromVertex(k)
+			for _, in := range incoming {
+				for _, out := range outgoing {
+					i, j := in.StartVertex, out.EndVertex
+					if i == k || j == k || i == j {
+						continue
+					}
+					check(i, k, j)
+				}
+			}
+		}
+		return
+	}
+
+	analysis.TriangleSampled = true
+	for s := 0; s < triangleSampleCount; s++ {
+		i := rand.Intn(vertexCount)
+		k := rand.Intn(vertexCount)
+		j := rand.Intn(vertexCount)
+		if i == k || j == k || i == j {
+			continue
+		}
+		check(i, k, j)
+	}
+}
+
+// analyzeConnectivity sprawdza silną spójność (przeszukiwanie z wierzchołka 0 po krawędziach wychodzących
+// i wchodzących) oraz stopnie wierzchołków, a na tej podstawie warunki konieczne istnienia cyklu Hamiltona
+func analyzeConnectivity(g Graph, analysis *GraphAnalysis) {
+	vertexCount := g.GetVertexCount()
+	if vertexCount == 0 {
+		analysis.HamiltonianIssues = append(analysis.HamiltonianIssues, "graf nie ma wierzchołków")
+		return
+	}
+
+	forward := reachableVertices(vertexCount, func(v int) []int {
+		var next []int
+		for _, edge := range g.GetEdgesFromVertex(v) {
+			next = append(next, edge.EndVertex)
+		}
+		return next
+	})
+	backward := reachableVertices(vertexCount, func(v int) []int {
+		var next []int
+		for _, edge := range g.GetEdgesToVertex(v) {
+			next = append(next, edge.StartVertex)
+		}
+		return next
+	})
+	analysis.StronglyConnected = forward == vertexCount && backward == vertexCount
+
+	if vertexCount == 1 {
+		analysis.HamiltonianPossible = true
+		return
+	}
+
+	// Jedyny następnik (poprzednik) wierzchołka jest krawędzią wymuszoną - dwa wierzchołki
+	// nie mogą mieć tego samego jedynego następnika (poprzednika)
+	forcedSuccessor := make(map[int]int)
+	forcedPredecessor := make(map[int]int)
+	for v := 0; v < vertexCount; v++ {
+		successors := neighborsWithoutLoop(g.GetEdgesFromVertex(v), v, func(e Edge) int { return e.EndVertex })
+		predecessors := neighborsWithoutLoop(g.GetEdgesToVertex(v), v, func(e Edge) int { return e.StartVertex })
+		if len(successors) == 0 {
+			analysis.NoOutgoingEdges = append(analysis.NoOutgoingEdges, v)
+		}
+		if len(predecessors) == 0 {
+			analysis.NoIncomingEdges = append(analysis.NoIncomingEdges, v)
+		}
+		if len(successors) == 1 {
+			if other, ok := forcedSuccessor[successors[0]]; ok {
+				analysis.HamiltonianIssues = append(analysis.HamiltonianIssues,
+					fmt.Sprintf("wierzchołki %d i %d mają ten sam jedyny następnik %d", other, v, successors[0]))
+			}
+			forcedSuccessor[successors[0]] = v
+		}
+		if len(predecessors) == 1 {
+			if other, ok := forcedPredecessor[predecessors[0]]; ok {
+				analysis.HamiltonianIssues = append(analysis.HamiltonianIssues,
+					fmt.Sprintf("wierzchołki %d i %d mają ten sam jedyny poprzednik %d", other, v, predecessors[0]))
+			}
+			forcedPredecessor[predecessors[0]] = v
+		}
+	}
+
+	if len(analysis.NoOutgoingEdges) > 0 {
+		analysis.HamiltonianIssues = append(analysis.HamiltonianIssues,
+			fmt.Sprintf("wierzchołki bez krawędzi wychodzących: %v", analysis.NoOutgoingEdges))
+	}
+	if len(analysis.NoIncomingEdges) > 0 {
+		analysis.HamiltonianIssues = append(analysis.HamiltonianIssues,
+			fmt.Sprintf("wierzchołki bez krawędzi wchodzących: %v", analysis.NoIncomingEdges))
+	}
+	if !analysis.StronglyConnected {
+		analysis.HamiltonianIssues = append(analysis.HamiltonianIssues, "graf nie jest silnie spójny")
+	}
+	analysis.HamiltonianPossible = len(analysis.HamiltonianIssues) == 0
+}
+
+// reachableVertices zwraca liczbę wierzchołków osiągalnych z wierzchołka 0 (przeszukiwanie wszerz)
+func reachableVertices(vertexCount int, neighbors func(v int) []int) int {
+	visited := make([]bool, vertexCount)
+	visited[0] = true
+	queue := []int{0}
+	count := 1
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, u := range neighbors(v) {
+			if !visited[u] {
+				visited[u] = true
+				count++
+				queue = append(queue, u)
+			}
+		}
+	}
+	return count
+}
+
+// neighborsWithoutLoop zwraca sąsiadów wierzchołka v z listy krawędzi, pomijając pętlę v -> v
+func neighborsWithoutLoop(edges []Edge, v int, neighbor func(e Edge) int) []int {
+	var result []int
+	for _, edge := range edges {
+		if u := neighbor(edge); u != v {
+			result = append(result, u)
+		}
+	}
+	return result
+}
+
+// ToString zwraca czytelny raport z analizy
+func (a GraphAnalysis) ToString() string {
+	var sb strings.Builder
+	yesNo := func(b bool) string {
+		if b {
+			return "tak"
+		}
+		return "nie"
+	}
+
+	sb.WriteString(fmt.Sprintf("Liczba wierzchołków: %d\n", a.VertexCount))
+	sb.WriteString(fmt.Sprintf("Liczba krawędzi (bez pętli): %d\n", a.EdgeCount))
+	sb.WriteString(fmt.Sprintf("Gęstość względem noEdgeValue = %d: %.4f\n", a.NoEdgeValue, a.Density))
+	sb.WriteString(fmt.Sprintf("Macierz symetryczna: %s\n", yesNo(a.Symmetric)))
+	if a.EdgeCount > 0 {
+		sb.WriteString(fmt.Sprintf("Wagi: min %g, max %g, średnia %.4f, odchylenie standardowe %.4f\n",
+			a.MinWeight, a.MaxWeight, a.MeanWeight, a.StdDevWeight))
+	} else {
+		sb.WriteString("Wagi: brak krawędzi\n")
+	}
+
+	triangleScope := "wszystkie trójki"
+	if a.TriangleSampled {
+		triangleScope = "losowa próba trójek"
+	}
+	sb.WriteString(fmt.Sprintf("Naruszenia nierówności trójkąta: %d z %d (%.2f%%, %s)\n",
+		a.TriangleViolations, a.TriangleChecks, 100*a.TriangleViolationRatio(), triangleScope))
+
+	sb.WriteString(fmt.Sprintf("Silnie spójny: %s\n", yesNo(a.StronglyConnected)))
+	if a.HamiltonianPossible {
+		sb.WriteString("Warunki konieczne istnienia cyklu Hamiltona: spełnione\n")
+	} else {
+		sb.WriteString("Warunki konieczne istnienia cyklu Hamiltona: niespełnione (cykl Hamiltona nie istnieje)\n")
+		for _, issue := range a.HamiltonianIssues {
+			sb.WriteString("  - " + issue + "\n")
+		}
+	}
+	return sb.String()
+}
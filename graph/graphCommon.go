@@ -0,0 +1,90 @@
+package graph
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// Wspólne implementacje metod interfejsu Graph oparte wyłącznie na innych metodach interfejsu.
+// Korzystają z nich reprezentacje grafu inne niż AdjMatrixGraph.
+
+// hamiltonianPathGreedy buduje ścieżkę metodą najbliższego sąsiada, zaczynając od startVertex.
+// Zwraca nil, jeśli z bieżącego wierzchołka nie ma krawędzi do żadnego nieodwiedzonego wierzchołka.
+func hamiltonianPathGreedy(g Graph, startVertex int) []int {
+	vertexCount := g.GetVertexCount()
+	visited := make([]bool, vertexCount)
+	path := make([]int, 0, vertexCount+1)
+	path = append(path, startVertex)
+	visited[startVertex] = true
+	currentVertex := startVertex
+	for len(path) < vertexCount {
+		minEdgeWeight := math.MaxInt
+		nextVertex := -1
+		for _, edge := range g.GetEdgesFromVertex(currentVertex) {
+			if !visited[edge.EndVertex] && edge.Weight < minEdgeWeight {
+				minEdgeWeight = edge.Weight
+				nextVertex = edge.EndVertex
+			}
+		}
+		if nextVertex == -1 {
+			return nil
+		}
+		path = append(path, nextVertex)
+		currentVertex = nextVertex
+		visited[currentVertex] = true
+	}
+	path = append(path, startVertex)
+	return path
+}
+
+// hamiltonianPathRandom buduje losową permutację wierzchołków zaczynającą i kończącą się w startVertex
+func hamiltonianPathRandom(g Graph, startVertex int) []int {
+	vertexCount := g.GetVertexCount()
+	path := make([]int, 0, vertexCount+1)
+	path = append(path, startVertex)
+	for _, vertex := range rand.Perm(vertexCount) {
+		if vertex != startVertex {
+			path = append(path, vertex)
+		}
+	}
+	path = append(path, startVertex)
+	return path
+}
+
+// pathWithWeightsToString zwraca ścieżkę w postaci "v0--(w)-->v1..."
+func pathWithWeightsToString(g Graph, path []int) string {
+	var out strings.Builder
+	for i := 0; i < len(path)-1; i++ {
+		out.WriteString("v" + strconv.Itoa(path[i]) + "--(" + strconv.Itoa(g.GetEdge(path[i], path[i+1]).Weight) + ")-->")
+	}
+	out.WriteString("v" + strconv.Itoa(path[len(path)-1]))
+	return out.String()
+}
+
+// matrixToString zwraca macierz wag w postaci tabeli, tak jak AdjMatrixGraph.ToString
+func matrixToString(g Graph) string {
+	var out strings.Builder
+	vertexCount := g.GetVertexCount()
+
+	out.WriteString("\t|")
+	for i := 0; i < vertexCount; i++ {
+		out.WriteString("v" + strconv.Itoa(i) + "\t|")
+	}
+	out.WriteString("\n")
+	out.WriteString(strings.Repeat("-", (vertexCount+1)*8) + "\n")
+
+	for i := 0; i < vertexCount; i++ {
+		out.WriteString("v" + strconv.Itoa(i) + "\t|")
+		for j := 0; j < vertexCount; j++ {
+			out.WriteString(strconv.Itoa(g.GetEdge(i, j).Weight) + "\t|")
+		}
+		out.WriteString("\n")
+		if i < vertexCount-1 {
+			out.WriteString(strings.Repeat("-", (vertexCount+1)*8) + "\n")
+		}
+	}
+
+	return out.String()
+}
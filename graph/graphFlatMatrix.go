@@ -0,0 +1,214 @@
+package graph
+
+import (
+	"math"
+)
+
+// Szerokości komórek macierzy FlatMatrixGraph w bajtach
+const (
+	CellWidth32 = 4
+	CellWidth64 = 8
+)
+
+// FlatMatrixGraph przechowuje macierz wag w jednym ciągłym wycinku (wiersz po wierszu)
+// z komórkami int32 lub int64. W porównaniu z [][]int w AdjMatrixGraph nie wymaga osobnej alokacji
+// dla każdego wiersza, a przy komórkach int32 zajmuje połowę pamięci (16000 wierzchołków: 1 GB zamiast 2 GB).
+// Loadery zwracają błąd dla wag spoza zakresu int32; generatory i AddEdge takie wagi pomijają,
+// więc dla instancji z bardzo dużymi wagami należy użyć komórek CellWidth64.
+type FlatMatrixGraph struct {
+	cells32     []int32 // Używane przy szerokości CellWidth32
+	cells64     []int64 // Używane przy szerokości CellWidth64
+	cellWidth   int
+	vertexCount int
+	edgeCount   int
+	noEdgeValue int
+	coordinates []Coordinate
+	metadata    Metadata
+}
+
+// NewFlatMatrixGraph tworzy graf bez krawędzi z komórkami o szerokości cellWidth (CellWidth32 lub CellWidth64).
+// Jeśli noEdgeValue nie mieści się w int32, używane są komórki int64.
+func NewFlatMatrixGraph(vertexCount, noEdgeValue, cellWidth int) *FlatMatrixGraph {
+	newGraph := new(FlatMatrixGraph)
+	newGraph.cellWidth = cellWidth
+	if !fitsInInt32(noEdgeValue) {
+		newGraph.cellWidth = CellWidth64
+	}
+	newGraph.noEdgeValue = noEdgeValue
+	newGraph.resetMatrix(vertexCount)
+	for i := 0; i < vertexCount; i++ {
+		for j := 0; j < vertexCount; j++ {
+			newGraph.setWeight(i, j, noEdgeValue)
+		}
+	}
+	return newGraph
+}
+
+// GetCellWidth zwraca szerokość komórki macierzy w bajtach
+func (f *FlatMatrixGraph) GetCellWidth() int {
+	return f.cellWidth
+}
+
+// resetMatrix przydziela nową macierz vertexCount x vertexCount wypełnioną zerami.
+// Graf utworzony jako wartość zerowa struktury używa komórek int64.
+func (f *FlatMatrixGraph) resetMatrix(vertexCount int) {
+	if f.cellWidth != CellWidth32 {
+		f.cellWidth = CellWidth64
+	}
+	f.cells32, f.cells64 = nil, nil
+	if f.cellWidth == CellWidth32 {
+		f.cells32 = make([]int32, vertexCount*vertexCount)
+	} else {
+		f.cells64 = make([]int64, vertexCount*vertexCount)
+	}
+	f.vertexCount = vertexCount
+	f.edgeCount = -1
+}
+
+// setWeight ustawia wagę krawędzi; zwraca false, jeśli waga nie mieści się w komórce int32
+func (f *FlatMatrixGraph) setWeight(startVertex, endVertex, weight int) bool {
+	idx := startVertex*f.vertexCount + endVertex
+	if f.cells32 != nil {
+		if !fitsInInt32(weight) {
+			return false
+		}
+		f.cells32[idx] = int32(weight)
+	} else {
+		f.cells64[idx] = int64(weight)
+	}
+	return true
+}
+
+// weight odczytuje wagę krawędzi bez tworzenia struktury Edge
+func (f *FlatMatrixGraph) weight(startVertex, endVertex int) int {
+	idx := startVertex*f.vertexCount + endVertex
+	if f.cells32 != nil {
+		return int(f.cells32[idx])
+	}
+	return int(f.cells64[idx])
+}
+
+func (f *FlatMatrixGraph) GetMetadata() Metadata {
+	return f.metadata
+}
+
+func (f *FlatMatrixGraph) SetMetadata(metadata Metadata) {
+	f.metadata = metadata
+}
+
+func (f *FlatMatrixGraph) GetCoordinates() []Coordinate {
+	return f.coordinates
+}
+
+func (f *FlatMatrixGraph) SetCoordinates(coordinates []Coordinate) {
+	f.coordinates = coordinates
+}
+
+func (f *FlatMatrixGraph) GetNoEdgeValue() int {
+	return f.noEdgeValue
+}
+
+func (f *FlatMatrixGraph) SetNoEdgeValue(noEdgeValue int) {
+	f.noEdgeValue = noEdgeValue
+	f.edgeCount = -1
+}
+
+func (f *FlatMatrixGraph) GetVertexCount() int {
+	return f.vertexCount
+}
+
+func (f *FlatMatrixGraph) GetEdgeCount() int {
+	if f.edgeCount == -1 {
+		count := 0
+		for i := 0; i < f.vertexCount; i++ {
+			for j := 0; j < f.vertexCount; j++ {
+				if f.weight(i, j) != f.noEdgeValue {
+					count++
+				}
+			}
+		}
+		f.edgeCount = count
+	}
+	return f.edgeCount
+}
+
+func (f *FlatMatrixGraph) GetAllEdges() []Edge {
+	edges := make([]Edge, 0)
+	for i := 0; i < f.vertexCount; i++ {
+		edges = append(edges, f.GetEdgesFromVertex(i)...)
+	}
+	return edges
+}
+
+func (f *FlatMatrixGraph) GetEdgesFromVertex(startVertex int) []Edge {
+	edges := make([]Edge, 0)
+	for i := 0; i < f.vertexCount; i++ {
+		if w := f.weight(startVertex, i); w != f.noEdgeValue {
+			edges = append(edges, Edge{StartVertex: startVertex, EndVertex: i, Weight: w})
+		}
+	}
+	return edges
+}
+
+func (f *FlatMatrixGraph) GetEdgesToVertex(endVertex int) []Edge {
+	edges := make([]Edge, 0)
+	for i := 0; i < f.vertexCount; i++ {
+		if w := f.weight(i, endVertex); w != f.noEdgeValue {
+			edges = append(edges, Edge{StartVertex: i, EndVertex: endVertex, Weight: w})
+		}
+	}
+	return edges
+}
+
+func (f *FlatMatrixGraph) GetEdge(startVertex, endVertex int) Edge {
+	return Edge{StartVertex: startVertex, EndVertex: endVertex, Weight: f.weight(startVertex, endVertex)}
+}
+
+func (f *FlatMatrixGraph) GetMinEdgeFromWeight(vertex int) int {
+	minEdge := math.MaxInt
+	for i := 0; i < f.vertexCount; i++ {
+		if w := f.weight(vertex, i); w < minEdge && w != f.noEdgeValue {
+			minEdge = w
+		}
+	}
+	return minEdge
+}
+
+// AddEdge ustawia wagę krawędzi; waga spoza zakresu int32 w grafie z komórkami int32 jest ignorowana
+func (f *FlatMatrixGraph) AddEdge(startVertex, endVertex, weight int) {
+	f.setWeight(startVertex, endVertex, weight)
+	f.edgeCount = -1
+}
+
+func (f *FlatMatrixGraph) RemoveEdge(startVertex, endVertex int) {
+	f.setWeight(startVertex, endVertex, f.noEdgeValue)
+	f.edgeCount = -1
+}
+
+func (f *FlatMatrixGraph) IsAdjacent(startVertex, endVertex int) bool {
+	return f.weight(startVertex, endVertex) != f.noEdgeValue
+}
+
+func (f *FlatMatrixGraph) CalculatePathWeight(path []int) int {
+	weight := 0
+	for i := 0; i < len(path)-1; i++ {
+		weight += f.weight(path[i], path[i+1])
+	}
+	return weight
+}
+
+func (f *FlatMatrixGraph) PathWithWeightsToString(path []int) string {
+	return pathWithWeightsToString(f, path)
+}
+
+func (f *FlatMatrixGraph) GetHamiltonianPathGreedy(startVertex int) []int {
+	return hamiltonianPathGreedy(f, startVertex)
+}
+
+func (f *FlatMatrixGraph) GetHamiltonianPathRandom(startVertex int) []int {
+	return hamiltonianPathRandom(f, startVertex)
+}
+
+func (f *FlatMatrixGraph) ToString() string {
+	return matrixToString(f)
+}
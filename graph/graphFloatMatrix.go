@@ -0,0 +1,251 @@
+package graph
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// FloatMatrixGraph to graf z wagami zmiennoprzecinkowymi (np. zużycie paliwa, czas w minutach z częścią ułamkową),
+// przechowywanymi w jednym ciągłym wycinku float64. Brak krawędzi jest oznaczany wewnętrznie przez NaN,
+// a metody całkowitoliczbowe interfejsu Graph zwracają dla niego noEdgeValue, a dla pozostałych krawędzi
+// wagę zaokrągloną do najbliższej liczby całkowitej. Dokładne koszty udostępniają metody interfejsu FloatGraph.
+type FloatMatrixGraph struct {
+	weights     []float64
+	vertexCount int
+	edgeCount   int
+	noEdgeValue int
+	coordinates []Coordinate
+	metadata    Metadata
+}
+
+func NewFloatMatrixGraph(vertexCount, noEdgeValue int) *FloatMatrixGraph {
+	newGraph := new(FloatMatrixGraph)
+	newGraph.noEdgeValue = noEdgeValue
+	newGraph.resetMatrix(vertexCount)
+	for i := range newGraph.weights {
+		newGraph.weights[i] = math.NaN()
+	}
+	newGraph.edgeCount = 0
+	return newGraph
+}
+
+// resetMatrix przydziela nową macierz vertexCount x vertexCount wypełnioną zerami
+func (f *FloatMatrixGraph) resetMatrix(vertexCount int) {
+	f.weights = make([]float64, vertexCount*vertexCount)
+	f.vertexCount = vertexCount
+	f.edgeCount = -1
+}
+
+// setWeight ustawia całkowitą wagę krawędzi; wartość noEdgeValue oznacza brak krawędzi
+func (f *FloatMatrixGraph) setWeight(startVertex, endVertex, weight int) bool {
+	if weight == f.noEdgeValue {
+		f.weights[startVertex*f.vertexCount+endVertex] = math.NaN()
+	} else {
+		f.weights[startVertex*f.vertexCount+endVertex] = float64(weight)
+	}
+	return true
+}
+
+// setWeightFloat ustawia zmiennoprzecinkową wagę krawędzi bez zmiany licznika krawędzi
+func (f *FloatMatrixGraph) setWeightFloat(startVertex, endVertex int, weight float64) {
+	f.weights[startVertex*f.vertexCount+endVertex] = weight
+}
+
+// AddEdgeFloat dodaje krawędź o wadze zmiennoprzecinkowej
+func (f *FloatMatrixGraph) AddEdgeFloat(startVertex, endVertex int, weight float64) {
+	f.setWeightFloat(startVertex, endVertex, weight)
+	f.edgeCount = -1
+}
+
+func (f *FloatMatrixGraph) GetEdgeWeightFloat(startVertex, endVertex int) float64 {
+	w := f.weights[startVertex*f.vertexCount+endVertex]
+	if math.IsNaN(w) {
+		return float64(f.noEdgeValue)
+	}
+	return w
+}
+
+func (f *FloatMatrixGraph) CalculatePathWeightFloat(path []int) float64 {
+	weight := 0.0
+	for i := 0; i < len(path)-1; i++ {
+		weight += f.GetEdgeWeightFloat(path[i], path[i+1])
+	}
+	return weight
+}
+
+func (f *FloatMatrixGraph) GetMetadata() Metadata {
+	return f.metadata
+}
+
+func (f *FloatMatrixGraph) SetMetadata(metadata Metadata) {
+	f.metadata = metadata
+}
+
+func (f *FloatMatrixGraph) GetCoordinates() []Coordinate {
+	return f.coordinates
+}
+
+func (f *FloatMatrixGraph) SetCoordinates(coordinates []Coordinate) {
+	f.coordinates = coordinates
+}
+
+func (f *FloatMatrixGraph) GetNoEdgeValue() int {
+	return f.noEdgeValue
+}
+
+// SetNoEdgeValue zmienia wartość zwracaną dla brakujących krawędzi; istniejące krawędzie pozostają bez zmian
+func (f *FloatMatrixGraph) SetNoEdgeValue(noEdgeValue int) {
+	f.noEdgeValue = noEdgeValue
+}
+
+func (f *FloatMatrixGraph) GetVertexCount() int {
+	return f.vertexCount
+}
+
+func (f *FloatMatrixGraph) GetEdgeCount() int {
+	if f.edgeCount == -1 {
+		count := 0
+		for _, w := range f.weights {
+			if !math.IsNaN(w) {
+				count++
+			}
+		}
+		f.edgeCount = count
+	}
+	return f.edgeCount
+}
+
+func (f *FloatMatrixGraph) GetAllEdges() []Edge {
+	edges := make([]Edge, 0)
+	for i := 0; i < f.vertexCount; i++ {
+		edges = append(edges, f.GetEdgesFromVertex(i)...)
+	}
+	return edges
+}
+
+func (f *FloatMatrixGraph) GetEdgesFromVertex(startVertex int) []Edge {
+	edges := make([]Edge, 0)
+	for i := 0; i < f.vertexCount; i++ {
+		if f.IsAdjacent(startVertex, i) {
+			edges = append(edges, f.GetEdge(startVertex, i))
+		}
+	}
+	return edges
+}
+
+func (f *FloatMatrixGraph) GetEdgesToVertex(endVertex int) []Edge {
+	edges := make([]Edge, 0)
+	for i := 0; i < f.vertexCount; i++ {
+		if f.IsAdjacent(i, endVertex) {
+			edges = append(edges, f.GetEdge(i, endVertex))
+		}
+	}
+	return edges
+}
+
+// GetEdge zwraca krawędź z wagą zaokrągloną do najbliższej liczby całkowitej
+func (f *FloatMatrixGraph) GetEdge(startVertex, endVertex int) Edge {
+	weight := f.noEdgeValue
+	if f.IsAdjacent(startVertex, endVertex) {
+		weight = int(math.Round(f.weights[startVertex*f.vertexCount+endVertex]))
+	}
+	return Edge{StartVertex: startVertex, EndVertex: endVertex, Weight: weight}
+}
+
+func (f *FloatMatrixGraph) GetMinEdgeFromWeight(vertex int) int {
+	minEdge := math.MaxInt
+	for _, edge := range f.GetEdgesFromVertex(vertex) {
+		if edge.Weight < minEdge {
+			minEdge = edge.Weight
+		}
+	}
+	return minEdge
+}
+
+func (f *FloatMatrixGraph) AddEdge(startVertex, endVertex, weight int) {
+	f.AddEdgeFloat(startVertex, endVertex, float64(weight))
+}
+
+func (f *FloatMatrixGraph) RemoveEdge(startVertex, endVertex int) {
+	f.weights[startVertex*f.vertexCount+endVertex] = math.NaN()
+	f.edgeCount = -1
+}
+
+func (f *FloatMatrixGraph) IsAdjacent(startVertex, endVertex int) bool {
+	return !math.IsNaN(f.weights[startVertex*f.vertexCount+endVertex])
+}
+
+// CalculatePathWeight zwraca sumę zaokrąglonych wag krawędzi (tak jak GetEdge);
+// dokładną wagę zwraca CalculatePathWeightFloat
+func (f *FloatMatrixGraph) CalculatePathWeight(path []int) int {
+	weight := 0
+	for i := 0; i < len(path)-1; i++ {
+		weight += f.GetEdge(path[i], path[i+1]).Weight
+	}
+	return weight
+}
+
+func (f *FloatMatrixGraph) PathWithWeightsToString(path []int) string {
+	var out strings.Builder
+	for i := 0; i < len(path)-1; i++ {
+		out.WriteString("v" + strconv.Itoa(path[i]) + "--(" + formatEdgeWeight(f, path[i], path[i+1]) + ")-->")
+	}
+	out.WriteString("v" + strconv.Itoa(path[len(path)-1]))
+	return out.String()
+}
+
+// GetHamiltonianPathGreedy wybiera najbliższego sąsiada według dokładnych wag zmiennoprzecinkowych
+func (f *FloatMatrixGraph) GetHamiltonianPathGreedy(startVertex int) []int {
+	visited := make([]bool, f.vertexCount)
+	path := make([]int, 0, f.vertexCount+1)
+	path = append(path, startVertex)
+	visited[startVertex] = true
+	currentVertex := startVertex
+	for len(path) < f.vertexCount {
+		minEdgeWeight := math.Inf(1)
+		nextVertex := -1
+		for i := 0; i < f.vertexCount; i++ {
+			if !visited[i] && f.IsAdjacent(currentVertex, i) && f.GetEdgeWeightFloat(currentVertex, i) < minEdgeWeight {
+				minEdgeWeight = f.GetEdgeWeightFloat(currentVertex, i)
+				nextVertex = i
+			}
+		}
+		if nextVertex == -1 {
+			return nil
+		}
+		path = append(path, nextVertex)
+		currentVertex = nextVertex
+		visited[currentVertex] = true
+	}
+	path = append(path, startVertex)
+	return path
+}
+
+func (f *FloatMatrixGraph) GetHamiltonianPathRandom(startVertex int) []int {
+	return hamiltonianPathRandom(f, startVertex)
+}
+
+func (f *FloatMatrixGraph) ToString() string {
+	var out strings.Builder
+
+	out.WriteString("\t|")
+	for i := 0; i < f.vertexCount; i++ {
+		out.WriteString("v" + strconv.Itoa(i) + "\t|")
+	}
+	out.WriteString("\n")
+	out.WriteString(strings.Repeat("-", (f.vertexCount+1)*8) + "\n")
+
+	for i := 0; i < f.vertexCount; i++ {
+		out.WriteString("v" + strconv.Itoa(i) + "\t|")
+		for j := 0; j < f.vertexCount; j++ {
+			out.WriteString(formatEdgeWeight(f, i, j) + "\t|")
+		}
+		out.WriteString("\n")
+		if i < f.vertexCount-1 {
+			out.WriteString(strings.Repeat("-", (f.vertexCount+1)*8) + "\n")
+		}
+	}
+
+	return out.String()
+}
@@ -0,0 +1,51 @@
+package graph
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+)
+
+// Wersja formatu danych haszowanych przez HashGraph - zmiana formatu musi ją zwiększyć,
+// aby stare wpisy pamięci podręcznej rozwiązań nie pasowały do nowych haszy
+const graphHashVersion = "graph-hash-v1"
+
+// HashGraph zwraca stabilny skrót SHA-256 (szesnastkowo) zawartości grafu: liczby wierzchołków, noEdgeValue
+// i wszystkich wag macierzy (brakujące krawędzie jako noEdgeValue). Skrót nie zależy od reprezentacji,
+// metadanych ani współrzędnych, ale grafy FloatGraph są haszowane osobno od całkowitoliczbowych,
+// bo solvery liczą dla nich inne koszty.
+func HashGraph(g Graph) string {
+	vertexCount := g.GetVertexCount()
+	noEdgeValue := g.GetNoEdgeValue()
+	fg, isFloat := g.(FloatGraph)
+
+	hash := sha256.New()
+	hash.Write([]byte(graphHashVersion))
+	kind := byte('i')
+	if isFloat {
+		kind = 'f'
+	}
+	buffer := make([]byte, 8)
+	write := func(value uint64) {
+		binary.LittleEndian.PutUint64(buffer, value)
+		hash.Write(buffer)
+	}
+	hash.Write([]byte{kind})
+	write(uint64(vertexCount))
+	write(uint64(int64(noEdgeValue)))
+
+	for i := 0; i < vertexCount; i++ {
+		for j := 0; j < vertexCount; j++ {
+			switch {
+			case !g.IsAdjacent(i, j):
+				write(uint64(int64(noEdgeValue)))
+			case isFloat:
+				write(math.Float64bits(fg.GetEdgeWeightFloat(i, j)))
+			default:
+				write(uint64(int64(g.GetEdge(i, j).Weight)))
+			}
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
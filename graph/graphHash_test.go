@@ -0,0 +1,54 @@
+package graph
+
+import "testing"
+
+func TestHashGraph(t *testing.T) {
+	fill := func(g MatrixGraph) MatrixGraph {
+		fillGeneratedGraph(g, 6, -1, "test", 1, func(i, j int) int {
+			return 10*i + j + 1
+		})
+		return g
+	}
+	base := HashGraph(fill(NewAdjMatrixGraph(0, -1)))
+
+	tests := []struct {
+		name  string
+		graph func() Graph
+		same  bool
+	}{
+		{"ta sama macierz", func() Graph { return fill(NewAdjMatrixGraph(0, -1)) }, true},
+		{"płaska macierz int32", func() Graph { return fill(NewFlatMatrixGraph(0, -1, CellWidth32)) }, true},
+		{"listy sąsiedztwa", func() Graph { return fill(NewAdjListGraph(0, -1)) }, true},
+		{"inne metadane", func() Graph {
+			g := fill(NewAdjMatrixGraph(0, -1))
+			g.SetMetadata(Metadata{Name: "inna"})
+			return g
+		}, true},
+		{"zmieniona waga", func() Graph {
+			g := fill(NewAdjMatrixGraph(0, -1))
+			g.AddEdge(2, 3, 1000)
+			return g
+		}, false},
+		{"usunięta krawędź", func() Graph {
+			g := fill(NewAdjMatrixGraph(0, -1))
+			g.RemoveEdge(2, 3)
+			return g
+		}, false},
+		{"inne noEdgeValue", func() Graph {
+			g := fill(NewAdjMatrixGraph(0, -1))
+			g.SetNoEdgeValue(-2)
+			return g
+		}, false},
+		{"wagi zmiennoprzecinkowe", func() Graph { return fill(NewFloatMatrixGraph(0, -1)) }, false},
+		{"inna liczba wierzchołków", func() Graph {
+			g := NewAdjMatrixGraph(0, -1)
+			fillGeneratedGraph(g, 5, -1, "test", 1, func(i, j int) int { return 10*i + j + 1 })
+			return g
+		}, false},
+	}
+	for _, test := range tests {
+		if got := HashGraph(test.graph()) == base; got != test.same {
+			t.Errorf("%s: równy skrót = %v, oczekiwano %v", test.name, got, test.same)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package graph
+
+import (
+	"bufio"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ImplicitCoordinateGraph przechowuje wyłącznie współrzędne wierzchołków i funkcję odległości,
+// a wagi krawędzi wylicza przy każdym odczycie. Zużywa pamięć O(n) zamiast O(n²), dzięki czemu
+// pozwala uruchamiać SA i TS na instancjach euklidesowych i geograficznych z dziesiątkami tysięcy punktów.
+// Opcjonalna pamięć podręczna LRU przechowuje ostatnio wyliczone wagi (przydatne dla kosztownych funkcji, np. GEO).
+// Graf jest pełny; przekątna ma wagę noEdgeValue. Krawędzie zmienione przez AddEdge i RemoveEdge
+// są zapamiętywane osobno i mają pierwszeństwo przed wyliczoną odległością.
+// Istnienie krawędzi nie zależy od wyliczonej wagi: odległość równa noEdgeValue (np. 0 dla punktów
+// o tych samych współrzędnych przy noEdgeValue 0) nadal jest krawędzią, brakuje tylko krawędzi usuniętych.
+type ImplicitCoordinateGraph struct {
+	coordinates []Coordinate
+	distance    DistanceFunction
+	noEdgeValue int
+	edgeCount   int
+	overrides   map[int]int      // Wagi krawędzi zmienionych ręcznie (klucz: startVertex*n + endVertex)
+	removed     map[int]struct{} // Krawędzie usunięte przez RemoveEdge (ten sam klucz)
+	cache       *weightCache
+	cacheHits   int
+	cacheMisses int
+	metadata    Metadata
+}
+
+// NewImplicitCoordinateGraph tworzy graf na podstawie współrzędnych i funkcji odległości.
+// cacheSize > 0 włącza pamięć podręczną LRU o podanej liczbie wag; 0 wyłącza ją.
+func NewImplicitCoordinateGraph(coordinates []Coordinate, distance DistanceFunction, noEdgeValue, cacheSize int) *ImplicitCoordinateGraph {
+	newGraph := &ImplicitCoordinateGraph{
+		coordinates: coordinates,
+		distance:    distance,
+		noEdgeValue: noEdgeValue,
+		edgeCount:   len(coordinates) * (len(coordinates) - 1),
+		overrides:   make(map[int]int),
+		removed:     make(map[int]struct{}),
+	}
+	if cacheSize > 0 {
+		newGraph.cache = newWeightCache(cacheSize)
+	}
+	return newGraph
+}
+
+// LoadImplicitGraphFromTSPLIBFile wczytuje instancję TSPLIB ze współrzędnymi (EUC_2D, CEIL_2D, ATT, GEO, MAN_2D, MAX_2D)
+// bez budowania macierzy wag. Pliki z jawną macierzą (EXPLICIT) nie są obsługiwane.
+func LoadImplicitGraphFromTSPLIBFile(filePath string, noEdgeValue, cacheSize int) (*ImplicitCoordinateGraph, error) {
+	file, err := openInputFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	instance, err := parseTSPLIB(newLineScanner(bufio.NewReader(file)))
+	if err != nil {
+		return nil, err
+	}
+	if instance.edgeWeightType == "" || instance.edgeWeightType == EdgeWeightTypeExplicit {
+		return nil, errors.New("graf wyliczany ze współrzędnych wymaga instancji TSPLIB z sekcją NODE_COORD_SECTION")
+	}
+	distance, err := instance.coordinateDistance()
+	if err != nil {
+		return nil, err
+	}
+
+	g := NewImplicitCoordinateGraph(instance.coordinates, distance, noEdgeValue, cacheSize)
+	g.SetMetadata(Metadata{Name: instance.name, Comment: instance.comment, EdgeWeightType: instance.edgeWeightType})
+	return g, nil
+}
+
+// CacheStats zwraca liczbę zapamiętanych wag oraz liczbę trafień i chybień pamięci podręcznej
+func (g *ImplicitCoordinateGraph) CacheStats() (size, hits, misses int) {
+	if g.cache == nil {
+		return 0, g.cacheHits, g.cacheMisses
+	}
+	return g.cache.len(), g.cacheHits, g.cacheMisses
+}
+
+// weight zwraca wagę krawędzi: zmienioną ręcznie, zapamiętaną lub wyliczoną ze współrzędnych
+func (g *ImplicitCoordinateGraph) weight(startVertex, endVertex int) int {
+	key := startVertex*len(g.coordinates) + endVertex
+	if len(g.overrides) > 0 || len(g.removed) > 0 {
+		if _, ok := g.removed[key]; ok {
+			return g.noEdgeValue
+		}
+		if w, ok := g.overrides[key]; ok {
+			return w
+		}
+	}
+	if startVertex == endVertex {
+		return g.noEdgeValue
+	}
+	if g.cache == nil {
+		return g.distance(g.coordinates[startVertex], g.coordinates[endVertex])
+	}
+	if w, ok := g.cache.get(key); ok {
+		g.cacheHits++
+		return w
+	}
+	g.cacheMisses++
+	w := g.distance(g.coordinates[startVertex], g.coordinates[endVertex])
+	g.cache.put(key, w)
+	return w
+}
+
+func (g *ImplicitCoordinateGraph) GetCoordinates() []Coordinate {
+	return g.coordinates
+}
+
+func (g *ImplicitCoordinateGraph) GetMetadata() Metadata {
+	return g.metadata
+}
+
+func (g *ImplicitCoordinateGraph) SetMetadata(metadata Metadata) {
+	g.metadata = metadata
+}
+
+func (g *ImplicitCoordinateGraph) GetNoEdgeValue() int {
+	return g.noEdgeValue
+}
+
+func (g *ImplicitCoordinateGraph) SetNoEdgeValue(noEdgeValue int) {
+	g.noEdgeValue = noEdgeValue
+}
+
+func (g *ImplicitCoordinateGraph) GetVertexCount() int {
+	return len(g.coordinates)
+}
+
+func (g *ImplicitCoordinateGraph) GetEdgeCount() int {
+	return g.edgeCount
+}
+
+// GetAllEdges wylicza wszystkie n² wag - dla dużych instancji należy korzystać z GetEdgesFromVertex lub GetEdge
+func (g *ImplicitCoordinateGraph) GetAllEdges() []Edge {
+	edges := make([]Edge, 0, g.edgeCount)
+	for i := 0; i < len(g.coordinates); i++ {
+		edges = append(edges, g.GetEdgesFromVertex(i)...)
+	}
+	return edges
+}
+
+func (g *ImplicitCoordinateGraph) GetEdgesFromVertex(startVertex int) []Edge {
+	edges := make([]Edge, 0, len(g.coordinates))
+	for i := 0; i < len(g.coordinates); i++ {
+		if g.IsAdjacent(startVertex, i) {
+			edges = append(edges, Edge{StartVertex: startVertex, EndVertex: i, Weight: g.weight(startVertex, i)})
+		}
+	}
+	return edges
+}
+
+func (g *ImplicitCoordinateGraph) GetEdgesToVertex(endVertex int) []Edge {
+	edges := make([]Edge, 0, len(g.coordinates))
+	for i := 0; i < len(g.coordinates); i++ {
+		if g.IsAdjacent(i, endVertex) {
+			edges = append(edges, Edge{StartVertex: i, EndVertex: endVertex, Weight: g.weight(i, endVertex)})
+		}
+	}
+	return edges
+}
+
+func (g *ImplicitCoordinateGraph) GetEdge(startVertex, endVertex int) Edge {
+	return Edge{StartVertex: startVertex, EndVertex: endVertex, Weight: g.weight(startVertex, endVertex)}
+}
+
+func (g *ImplicitCoordinateGraph) GetMinEdgeFromWeight(vertex int) int {
+	minEdge := math.MaxInt
+	for i := 0; i < len(g.coordinates); i++ {
+		if !g.IsAdjacent(vertex, i) {
+			continue
+		}
+		if w := g.weight(vertex, i); w < minEdge {
+			minEdge = w
+		}
+	}
+	return minEdge
+}
+
+// AddEdge nadpisuje wyliczoną wagę krawędzi podaną wartością; waga równa noEdgeValue usuwa krawędź (jak w grafach macierzowych)
+func (g *ImplicitCoordinateGraph) AddEdge(startVertex, endVertex, weight int) {
+	if weight == g.noEdgeValue {
+		g.RemoveEdge(startVertex, endVertex)
+		return
+	}
+	wasAdjacent := g.IsAdjacent(startVertex, endVertex)
+	key := startVertex*len(g.coordinates) + endVertex
+	delete(g.removed, key)
+	g.overrides[key] = weight
+	g.updateEdgeCount(wasAdjacent, true)
+}
+
+// RemoveEdge oznacza krawędź jako nieistniejącą (waga noEdgeValue)
+func (g *ImplicitCoordinateGraph) RemoveEdge(startVertex, endVertex int) {
+	wasAdjacent := g.IsAdjacent(startVertex, endVertex)
+	key := startVertex*len(g.coordinates) + endVertex
+	delete(g.overrides, key)
+	g.removed[key] = struct{}{}
+	g.updateEdgeCount(wasAdjacent, false)
+}
+
+// updateEdgeCount aktualizuje licznik krawędzi po zmianie jednej z nich
+func (g *ImplicitCoordinateGraph) updateEdgeCount(wasAdjacent, isAdjacent bool) {
+	if wasAdjacent && !isAdjacent {
+		g.edgeCount--
+	} else if !wasAdjacent && isAdjacent {
+		g.edgeCount++
+	}
+}
+
+// IsAdjacent zwraca true dla każdej pary różnych wierzchołków, która nie została usunięta, oraz dla pętli dodanych przez AddEdge
+func (g *ImplicitCoordinateGraph) IsAdjacent(startVertex, endVertex int) bool {
+	key := startVertex*len(g.coordinates) + endVertex
+	if _, ok := g.removed[key]; ok {
+		return false
+	}
+	if _, ok := g.overrides[key]; ok {
+		return true
+	}
+	return startVertex != endVertex
+}
+
+func (g *ImplicitCoordinateGraph) CalculatePathWeight(path []int) int {
+	weight := 0
+	for i := 0; i < len(path)-1; i++ {
+		weight += g.weight(path[i], path[i+1])
+	}
+	return weight
+}
+
+func (g *ImplicitCoordinateGraph) PathWithWeightsToString(path []int) string {
+	return pathWithWeightsToString(g, path)
+}
+
+func (g *ImplicitCoordinateGraph) GetHamiltonianPathGreedy(startVertex int) []int {
+	return hamiltonianPathGreedy(g, startVertex)
+}
+
+func (g *ImplicitCoordinateGraph) GetHamiltonianPathRandom(startVertex int) []int {
+	return hamiltonianPathRandom(g, startVertex)
+}
+
+// ToString zwraca listę współrzędnych wierzchołków (macierz n x n byłaby zbyt duża dla typowych instancji)
+func (g *ImplicitCoordinateGraph) ToString() string {
+	var out strings.Builder
+	out.WriteString("Graf wyliczany ze współrzędnych, liczba wierzchołków: " + strconv.Itoa(len(g.coordinates)) + "\n")
+	for i, c := range g.coordinates {
+		out.WriteString("v" + strconv.Itoa(i) + ": (" + strconv.FormatFloat(c.X, 'g', -1, 64) + ", " + strconv.FormatFloat(c.Y, 'g', -1, 64) + ")\n")
+	}
+	return out.String()
+}
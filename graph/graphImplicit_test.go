@@ -0,0 +1,66 @@
+package graph
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestImplicitCoordinateGraphAdjacency(t *testing.T) {
+	// Wierzchołki 0 i 1 mają te same współrzędne, a 2 i 3 leżą w odległości zaokrąglanej do 0
+	coordinates := []Coordinate{{0, 0}, {0, 0}, {10, 0}, {10, 0.3}}
+	distance, _ := DistanceFunctionForType(EdgeWeightTypeEuc2D)
+
+	tests := []struct {
+		name      string
+		modify    func(g *ImplicitCoordinateGraph)
+		missing   [][2]int // Pary różnych wierzchołków bez krawędzi
+		edgeCount int
+	}{
+		{"bez zmian", func(g *ImplicitCoordinateGraph) {}, nil, 12},
+		{"usunięta krawędź o wadze 0", func(g *ImplicitCoordinateGraph) { g.RemoveEdge(0, 1) }, [][2]int{{0, 1}}, 11},
+		{"usunięta i przywrócona krawędź", func(g *ImplicitCoordinateGraph) {
+			g.RemoveEdge(2, 0)
+			g.AddEdge(2, 0, 3)
+		}, nil, 12},
+		{"AddEdge z wagą noEdgeValue", func(g *ImplicitCoordinateGraph) { g.AddEdge(3, 2, g.GetNoEdgeValue()) }, [][2]int{{3, 2}}, 11},
+		{"nadpisana waga", func(g *ImplicitCoordinateGraph) { g.AddEdge(1, 3, 7) }, nil, 12},
+	}
+
+	for _, noEdgeValue := range []int{0, -1} {
+		for _, tt := range tests {
+			t.Run(tt.name+"/noEdgeValue="+strconv.Itoa(noEdgeValue), func(t *testing.T) {
+				g := NewImplicitCoordinateGraph(coordinates, distance, noEdgeValue, 4)
+				tt.modify(g)
+				missing := make(map[[2]int]bool)
+				for _, pair := range tt.missing {
+					missing[pair] = true
+				}
+
+				for i := range coordinates {
+					for j := range coordinates {
+						if g.IsAdjacent(i, j) != (i != j && !missing[[2]int{i, j}]) {
+							t.Errorf("noEdgeValue %d: krawędź %d -> %d: IsAdjacent = %t", noEdgeValue, i, j, g.IsAdjacent(i, j))
+						}
+					}
+				}
+				if g.GetEdgeCount() != tt.edgeCount || len(g.GetAllEdges()) != tt.edgeCount {
+					t.Errorf("noEdgeValue %d: GetEdgeCount = %d, GetAllEdges: %d, oczekiwano %d",
+						noEdgeValue, g.GetEdgeCount(), len(g.GetAllEdges()), tt.edgeCount)
+				}
+				if IsCompleteGraph(g) != (len(tt.missing) == 0) {
+					t.Errorf("noEdgeValue %d: IsCompleteGraph = %t", noEdgeValue, IsCompleteGraph(g))
+				}
+				if g.GetMinEdgeFromWeight(0) != 0 && !missing[[2]int{0, 1}] {
+					t.Errorf("noEdgeValue %d: najmniejsza waga z 0 = %d, oczekiwano 0", noEdgeValue, g.GetMinEdgeFromWeight(0))
+				}
+				outgoing := 0
+				for v := range coordinates {
+					outgoing += len(g.GetEdgesFromVertex(v))
+				}
+				if outgoing != tt.edgeCount {
+					t.Errorf("noEdgeValue %d: GetEdgesFromVertex zwraca %d krawędzi, oczekiwano %d", noEdgeValue, outgoing, tt.edgeCount)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Subgraph to widok grafu ograniczony do wybranych wierzchołków, ponumerowanych od 0 w kolejności wyboru.
+// Wierzchołek lokalny v odpowiada wierzchołkowi OriginalVertex(v) grafu nadrzędnego.
+type Subgraph interface {
+	Graph
+	Parent() Graph
+	OriginalVertex(v int) int
+	LocalVertex(originalVertex int) (int, bool)
+	ToOriginalPath(path []int) []int
+	FromOriginalPath(path []int) ([]int, error)
+}
+
+// SubgraphView implementuje Subgraph bez kopiowania wag - każdy odczyt jest tłumaczony na graf nadrzędny.
+// AddEdge, RemoveEdge i SetNoEdgeValue zmieniają graf nadrzędny.
+type SubgraphView struct {
+	parent   Graph
+	vertices []int       // Numer oryginalny każdego wierzchołka lokalnego
+	local    map[int]int // Numer lokalny każdego wybranego wierzchołka oryginalnego
+	metadata Metadata
+}
+
+// floatSubgraphView to widok grafu z wagami zmiennoprzecinkowymi, zachowujący dokładne wagi (FloatGraph)
+type floatSubgraphView struct {
+	*SubgraphView
+	parent FloatGraph
+}
+
+// NewSubgraph tworzy widok grafu g ograniczony do podanych wierzchołków (bez powtórzeń).
+// Dla grafu FloatGraph widok również implementuje FloatGraph.
+func NewSubgraph(g Graph, vertices []int) (Subgraph, error) {
+	if len(vertices) == 0 {
+		return nil, errors.New("lista wierzchołków podgrafu jest pusta")
+	}
+	view := &SubgraphView{
+		parent:   g,
+		vertices: make([]int, len(vertices)),
+		local:    make(map[int]int, len(vertices)),
+	}
+	copy(view.vertices, vertices)
+	for i, v := range vertices {
+		if v < 0 || v >= g.GetVertexCount() {
+			return nil, fmt.Errorf("wierzchołek %d poza zakresem grafu", v)
+		}
+		if _, ok := view.local[v]; ok {
+			return nil, fmt.Errorf("wierzchołek %d powtarza się na liście", v)
+		}
+		view.local[v] = i
+	}
+
+	metadata := GetGraphMetadata(g)
+	view.metadata = Metadata{Name: metadata.Name, EdgeWeightType: metadata.EdgeWeightType, Comment: fmt.Sprintf("Podgraf %d z %d wierzchołków", len(vertices), g.GetVertexCount())}
+	labels := make([]string, len(vertices))
+	for i, v := range vertices {
+		if v < len(metadata.VertexLabels) {
+			labels[i] = metadata.VertexLabels[v]
+		} else {
+			labels[i] = strconv.Itoa(v)
+		}
+	}
+	view.metadata.VertexLabels = labels
+
+	if fg, ok := g.(FloatGraph); ok {
+		return &floatSubgraphView{SubgraphView: view, parent: fg}, nil
+	}
+	return view, nil
+}
+
+// ParseVertexList odczytuje listę wierzchołków w postaci "0-19" lub "1,4,7" (można łączyć: "0-4,10,12-13")
+func ParseVertexList(text string, vertexCount int) ([]int, error) {
+	var vertices []int
+	for _, part := range strings.Split(text, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		first, last := part, part
+		if from, to, isRange := strings.Cut(part, "-"); isRange {
+			first, last = strings.TrimSpace(from), strings.TrimSpace(to)
+		}
+		start, err := strconv.Atoi(first)
+		if err != nil {
+			return nil, fmt.Errorf("nieprawidłowy wierzchołek: %s", first)
+		}
+		end, err := strconv.Atoi(last)
+		if err != nil {
+			return nil, fmt.Errorf("nieprawidłowy wierzchołek: %s", last)
+		}
+		if start > end {
+			return nil, fmt.Errorf("nieprawidłowy zakres: %s", part)
+		}
+		if start < 0 || end >= vertexCount {
+			return nil, fmt.Errorf("zakres %s wychodzi poza wierzchołki 0-%d", part, vertexCount-1)
+		}
+		for v := start; v <= end; v++ {
+			vertices = append(vertices, v)
+		}
+	}
+	if len(vertices) == 0 {
+		return nil, errors.New("nie podano żadnego wierzchołka")
+	}
+	return vertices, nil
+}
+
+// Parent zwraca graf nadrzędny
+func (s *SubgraphView) Parent() Graph {
+	return s.parent
+}
+
+// OriginalVertex zwraca numer wierzchołka lokalnego v w grafie nadrzędnym
+func (s *SubgraphView) OriginalVertex(v int) int {
+	return s.vertices[v]
+}
+
+// LocalVertex zwraca numer lokalny wierzchołka grafu nadrzędnego; false, jeśli nie należy on do podgrafu
+func (s *SubgraphView) LocalVertex(originalVertex int) (int, bool) {
+	v, ok := s.local[originalVertex]
+	return v, ok
+}
+
+// ToOriginalPath tłumaczy ścieżkę w numeracji podgrafu na numerację grafu nadrzędnego
+func (s *SubgraphView) ToOriginalPath(path []int) []int {
+	if path == nil {
+		return nil
+	}
+	originalPath := make([]int, len(path))
+	for i, v := range path {
+		originalPath[i] = s.vertices[v]
+	}
+	return originalPath
+}
+
+// FromOriginalPath tłumaczy ścieżkę w numeracji grafu nadrzędnego na numerację podgrafu
+func (s *SubgraphView) FromOriginalPath(path []int) ([]int, error) {
+	localPath := make([]int, len(path))
+	for i, v := range path {
+		local, ok := s.local[v]
+		if !ok {
+			return nil, fmt.Errorf("wierzchołek %d nie należy do podgrafu", v)
+		}
+		localPath[i] = local
+	}
+	return localPath, nil
+}
+
+func (s *SubgraphView) GetMetadata() Metadata {
+	return s.metadata
+}
+
+func (s *SubgraphView) SetMetadata(metadata Metadata) {
+	s.metadata = metadata
+}
+
+// GetCoordinates zwraca współrzędne wybranych wierzchołków lub nil, jeśli graf nadrzędny ich nie ma
+func (s *SubgraphView) GetCoordinates() []Coordinate {
+	cg, ok := s.parent.(CoordinateGraph)
+	if !ok || cg.GetCoordinates() == nil {
+		return nil
+	}
+	all := cg.GetCoordinates()
+	coordinates := make([]Coordinate, len(s.vertices))
+	for i, v := range s.vertices {
+		coordinates[i] = all[v]
+	}
+	return coordinates
+}
+
+func (s *SubgraphView) GetNoEdgeValue() int {
+	return s.parent.GetNoEdgeValue()
+}
+
+func (s *SubgraphView) SetNoEdgeValue(noEdgeValue int) {
+	s.parent.SetNoEdgeValue(noEdgeValue)
+}
+
+func (s *SubgraphView) GetVertexCount() int {
+	return len(s.vertices)
+}
+
+func (s *SubgraphView) GetEdgeCount() int {
+	count := 0
+	for i := range s.vertices {
+		for j := range s.vertices {
+			if s.IsAdjacent(i, j) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func (s *SubgraphView) GetAllEdges() []Edge {
+	edges := make([]Edge, 0)
+	for i := range s.vertices {
+		edges = append(edges, s.GetEdgesFromVertex(i)...)
+	}
+	return edges
+}
+
+func (s *SubgraphView) GetEdgesFromVertex(startVertex int) []Edge {
+	edges := make([]Edge, 0)
+	for j := range s.vertices {
+		if edge := s.GetEdge(startVertex, j); edge.Weight != s.GetNoEdgeValue() {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
+func (s *SubgraphView) GetEdgesToVertex(endVertex int) []Edge {
+	edges := make([]Edge, 0)
+	for i := range s.vertices {
+		if edge := s.GetEdge(i, endVertex); edge.Weight != s.GetNoEdgeValue() {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
+func (s *SubgraphView) GetEdge(startVertex, endVertex int) Edge {
+	weight := s.parent.GetEdge(s.vertices[startVertex], s.vertices[endVertex]).Weight
+	return Edge{StartVertex: startVertex, EndVertex: endVertex, Weight: weight}
+}
+
+func (s *SubgraphView) GetMinEdgeFromWeight(vertex int) int {
+	minEdge := math.MaxInt
+	for _, edge := range s.GetEdgesFromVertex(vertex) {
+		if edge.Weight < minEdge {
+			minEdge = edge.Weight
+		}
+	}
+	return minEdge
+}
+
+func (s *SubgraphView) AddEdge(startVertex, endVertex, weight int) {
+	s.parent.AddEdge(s.vertices[startVertex], s.vertices[endVertex], weight)
+}
+
+func (s *SubgraphView) RemoveEdge(startVertex, endVertex int) {
+	s.parent.RemoveEdge(s.vertices[startVertex], s.vertices[endVertex])
+}
+
+func (s *SubgraphView) IsAdjacent(startVertex, endVertex int) bool {
+	return s.parent.IsAdjacent(s.vertices[startVertex], s.vertices[endVertex])
+}
+
+func (s *SubgraphView) CalculatePathWeight(path []int) int {
+	return s.parent.CalculatePathWeight(s.ToOriginalPath(path))
+}
+
+func (s *SubgraphView) PathWithWeightsToString(path []int) string {
+	return pathWithWeightsToString(s, path)
+}
+
+func (s *SubgraphView) GetHamiltonianPathGreedy(startVertex int) []int {
+	return hamiltonianPathGreedy(s, startVertex)
+}
+
+func (s *SubgraphView) GetHamiltonianPathRandom(startVertex int) []int {
+	return hamiltonianPathRandom(s, startVertex)
+}
+
+// ToString zwraca macierz wag podgrafu i numery oryginalnych wierzchołków
+func (s *SubgraphView) ToString() string {
+	return "Podgraf, wierzchołki oryginalne: " + fmt.Sprint(s.vertices) + "\n" + matrixToString(s)
+}
+
+func (f *floatSubgraphView) GetEdgeWeightFloat(startVertex, endVertex int) float64 {
+	return f.parent.GetEdgeWeightFloat(f.vertices[startVertex], f.vertices[endVertex])
+}
+
+func (f *floatSubgraphView) CalculatePathWeightFloat(path []int) float64 {
+	return f.parent.CalculatePathWeightFloat(f.ToOriginalPath(path))
+}
+
+func (f *floatSubgraphView) PathWithWeightsToString(path []int) string {
+	var out strings.Builder
+	for i := 0; i < len(path)-1; i++ {
+		out.WriteString("v" + strconv.Itoa(path[i]) + "--(" + formatEdgeWeight(f, path[i], path[i+1]) + ")-->")
+	}
+	out.WriteString("v" + strconv.Itoa(path[len(path)-1]))
+	return out.String()
+}
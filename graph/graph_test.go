@@ -0,0 +1,41 @@
+package graph
+
+import "testing"
+
+// testRepresentations to reprezentacje, do których loadery i generatory zapisują dane (NewMatrixGraph)
+var testRepresentations = []string{
+	RepresentationAdjMatrix,
+	RepresentationFlat32,
+	RepresentationFlat64,
+	RepresentationAdjList,
+	RepresentationFloat,
+}
+
+// newTestGraph tworzy pusty graf o podanej reprezentacji (jak Menu.newGraph)
+func newTestGraph(t *testing.T, representation string, noEdgeValue int) MatrixGraph {
+	t.Helper()
+	g, err := NewMatrixGraph(representation, noEdgeValue)
+	if err != nil {
+		t.Fatalf("NewMatrixGraph(%s): %v", representation, err)
+	}
+	return g
+}
+
+// assertSameWeights sprawdza, czy grafy mają te same wierzchołki, noEdgeValue, krawędzie i dokładne wagi
+func assertSameWeights(t *testing.T, got, want Graph) {
+	t.Helper()
+	if got.GetVertexCount() != want.GetVertexCount() || got.GetNoEdgeValue() != want.GetNoEdgeValue() {
+		t.Fatalf("%d wierzchołków i noEdgeValue %d, oczekiwano %d i %d",
+			got.GetVertexCount(), got.GetNoEdgeValue(), want.GetVertexCount(), want.GetNoEdgeValue())
+	}
+	for i := 0; i < want.GetVertexCount(); i++ {
+		for j := 0; j < want.GetVertexCount(); j++ {
+			if got.IsAdjacent(i, j) != want.IsAdjacent(i, j) {
+				t.Fatalf("krawędź %d -> %d: IsAdjacent = %t, oczekiwano %t", i, j, got.IsAdjacent(i, j), want.IsAdjacent(i, j))
+			}
+			if want.IsAdjacent(i, j) && EdgeWeightFloat(got, i, j) != EdgeWeightFloat(want, i, j) {
+				t.Fatalf("krawędź %d -> %d: waga %v, oczekiwano %v", i, j, EdgeWeightFloat(got, i, j), EdgeWeightFloat(want, i, j))
+			}
+		}
+	}
+}
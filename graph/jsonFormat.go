@@ -0,0 +1,165 @@
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// jsonGraph to reprezentacja grafu w formacie JSON.
+// Wagi podaje się jako pełną macierz (matrix) albo jako listę krawędzi (edges) - wtedy brakujące pary
+// otrzymują wartość noEdgeValue. Wagi niecałkowite są dopuszczalne przy wczytywaniu do FloatMatrixGraph.
+type jsonGraph struct {
+	Name           string          `json:"name,omitempty"`
+	Comment        string          `json:"comment,omitempty"`
+	Seed           int64           `json:"seed,omitempty"`
+	EdgeWeightType string          `json:"edgeWeightType,omitempty"`
+	VertexCount    int             `json:"vertexCount"`
+	NoEdgeValue    int             `json:"noEdgeValue"`
+	Coordinates    [][2]float64    `json:"coordinates,omitempty"`
+	Matrix         [][]json.Number `json:"matrix,omitempty"`
+	Edges          []jsonEdge      `json:"edges,omitempty"`
+}
+
+type jsonEdge struct {
+	From   int         `json:"from"`
+	To     int         `json:"to"`
+	Weight json.Number `json:"weight"`
+}
+
+// SaveGraphToJSONFile zapisuje graf do pliku JSON.
+// Jeśli sparse = true, zapisywana jest lista istniejących krawędzi (GetAllEdges) zamiast pełnej macierzy.
+// Jeśli ścieżka kończy się na .gz, plik jest kompresowany algorytmem gzip.
+func SaveGraphToJSONFile(g Graph, filePath string, sparse bool) error {
+	file, err := createOutputFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	if err := writeJSON(file, g, sparse); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func writeJSON(w io.Writer, g Graph, sparse bool) error {
+	metadata := GetGraphMetadata(g)
+	out := jsonGraph{
+		Name:           metadata.Name,
+		Comment:        metadata.Comment,
+		Seed:           metadata.Seed,
+		EdgeWeightType: metadata.EdgeWeightType,
+		VertexCount:    g.GetVertexCount(),
+		NoEdgeValue:    g.GetNoEdgeValue(),
+	}
+
+	if cg, ok := g.(CoordinateGraph); ok {
+		for _, c := range cg.GetCoordinates() {
+			out.Coordinates = append(out.Coordinates, [2]float64{c.X, c.Y})
+		}
+	}
+
+	if sparse {
+		out.Edges = make([]jsonEdge, 0, g.GetEdgeCount())
+		for _, edge := range g.GetAllEdges() {
+			weight := json.Number(formatEdgeWeight(g, edge.StartVertex, edge.EndVertex))
+			out.Edges = append(out.Edges, jsonEdge{From: edge.StartVertex, To: edge.EndVertex, Weight: weight})
+		}
+	} else {
+		out.Matrix = make([][]json.Number, g.GetVertexCount())
+		for i := 0; i < g.GetVertexCount(); i++ {
+			out.Matrix[i] = make([]json.Number, g.GetVertexCount())
+			for j := 0; j < g.GetVertexCount(); j++ {
+				out.Matrix[i][j] = json.Number(formatEdgeWeight(g, i, j))
+			}
+		}
+	}
+
+	return json.NewEncoder(w).Encode(out)
+}
+
+// loadJSONFormat wczytuje graf z formatu JSON; wartość noEdgeValue z pliku zastępuje wartość grafu
+func loadJSONFormat(r io.Reader, graph MatrixGraph) error {
+	var in jsonGraph
+	if err := json.NewDecoder(r).Decode(&in); err != nil {
+		return fmt.Errorf("błąd dekodowania pliku JSON: %v", err)
+	}
+
+	vertexCount := in.VertexCount
+	if vertexCount == 0 {
+		vertexCount = len(in.Matrix)
+	}
+	if vertexCount <= 0 {
+		return errors.New("brak liczby wierzchołków w pliku JSON")
+	}
+	if in.Matrix != nil && in.Edges != nil {
+		return errors.New("plik JSON może zawierać macierz albo listę krawędzi, ale nie obie naraz")
+	}
+	if in.Coordinates != nil && len(in.Coordinates) != vertexCount {
+		return errors.New("liczba współrzędnych w pliku JSON nie zgadza się z liczbą wierzchołków")
+	}
+
+	var coordinates []Coordinate
+	for _, c := range in.Coordinates {
+		coordinates = append(coordinates, Coordinate{X: c[0], Y: c[1]})
+	}
+	metadata := Metadata{Name: in.Name, Comment: in.Comment, Seed: in.Seed, EdgeWeightType: in.EdgeWeightType}
+
+	// Wagi niecałkowite są zapisywane po zapisaniu wag całkowitych (setFloatWeight)
+	type floatCell struct {
+		from, to int
+		weight   string
+	}
+	var floatCells []floatCell
+	graph.SetNoEdgeValue(in.NoEdgeValue)
+
+	if in.Matrix == nil {
+		edges := make([]Edge, 0, len(in.Edges))
+		for _, edge := range in.Edges {
+			if edge.From < 0 || edge.From >= vertexCount || edge.To < 0 || edge.To >= vertexCount {
+				return fmt.Errorf("krawędź %d -> %d wychodzi poza zakres wierzchołków", edge.From, edge.To)
+			}
+			weight, err := strconv.Atoi(edge.Weight.String())
+			if err != nil {
+				floatCells = append(floatCells, floatCell{from: edge.From, to: edge.To, weight: edge.Weight.String()})
+				continue
+			}
+			edges = append(edges, Edge{StartVertex: edge.From, EndVertex: edge.To, Weight: weight})
+		}
+		if err := storeEdges(graph, vertexCount, edges, coordinates, metadata); err != nil {
+			return err
+		}
+	} else {
+		if len(in.Matrix) != vertexCount {
+			return errors.New("niewłaściwa liczba wierszy w macierzy sąsiedztwa")
+		}
+		matrix := make([][]int, vertexCount)
+		for i, row := range in.Matrix {
+			if len(row) != vertexCount {
+				return errors.New("niewłaściwa liczba elementów w wierszu macierzy")
+			}
+			matrix[i] = make([]int, vertexCount)
+			for j, value := range row {
+				weight, err := strconv.Atoi(value.String())
+				if err != nil {
+					floatCells = append(floatCells, floatCell{from: i, to: j, weight: value.String()})
+					weight = in.NoEdgeValue
+				}
+				matrix[i][j] = weight
+			}
+		}
+		if err := storeMatrix(graph, matrix, coordinates, metadata); err != nil {
+			return err
+		}
+	}
+
+	for _, cell := range floatCells {
+		if err := setFloatWeight(graph, cell.from, cell.to, cell.weight); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+)
+
+// MatrixReduction opisuje redukcję wierszy i kolumn macierzy wag.
+// Od każdej wagi w wierszu i odejmowane jest RowReductions[i], a potem od każdej wagi w kolumnie j - ColumnReductions[j].
+// Każda trasa używa dokładnie jednej krawędzi wychodzącej z każdego wierzchołka i jednej wchodzącej do niego,
+// więc jej koszt w grafie zredukowanym jest mniejszy o stałą Offset, a optymalne trasy obu grafów są te same.
+// Wagi zredukowane są nieujemne, dlatego Offset jest też dolnym ograniczeniem kosztu każdej trasy.
+type MatrixReduction struct {
+	RowReductions    []int
+	ColumnReductions []int
+	Offset           int // Suma redukcji wierszy i kolumn
+}
+
+// ReduceGraph zapisuje w target graf g po redukcji wierszy i kolumn i zwraca opis redukcji.
+// Redukcje są całkowite: dla wag niecałkowitych odejmowana jest część całkowita (podłoga) minimum,
+// a część ułamkowa zostaje w grafie zredukowanym, jeśli target obsługuje wagi zmiennoprzecinkowe.
+// Pętle (krawędzie i -> i) nie należą do żadnej trasy i nie są przenoszone; brakujące krawędzie pozostają brakujące.
+// Zwraca błąd, jeśli zredukowana waga jest równa noEdgeValue grafu docelowego lub nie mieści się w jego komórce.
+func ReduceGraph(g Graph, target MatrixGraph) (MatrixReduction, error) {
+	vertexCount := g.GetVertexCount()
+	weights, adjacent := WeightMatrix[float64](g)
+	_, floatSource := g.(FloatGraph)
+	_, floatTarget := target.(floatWeightSetter)
+	floatWeights := floatSource && floatTarget
+
+	reduction := MatrixReduction{
+		RowReductions:    make([]int, vertexCount),
+		ColumnReductions: make([]int, vertexCount),
+	}
+	exists := func(i, j int) bool {
+		return i != j && adjacent[i][j]
+	}
+
+	// Redukcja wierszy: najmniejsza krawędź wychodząca
+	for i := 0; i < vertexCount; i++ {
+		minWeight := math.Inf(1)
+		for j := 0; j < vertexCount; j++ {
+			if exists(i, j) {
+				minWeight = math.Min(minWeight, weights[i][j])
+			}
+		}
+		if !math.IsInf(minWeight, 1) {
+			reduction.RowReductions[i] = int(math.Floor(minWeight))
+		}
+	}
+
+	// Redukcja kolumn: najmniejsza krawędź wchodząca po redukcji wierszy
+	for j := 0; j < vertexCount; j++ {
+		minWeight := math.Inf(1)
+		for i := 0; i < vertexCount; i++ {
+			if exists(i, j) {
+				minWeight = math.Min(minWeight, weights[i][j]-float64(reduction.RowReductions[i]))
+			}
+		}
+		if !math.IsInf(minWeight, 1) {
+			reduction.ColumnReductions[j] = int(math.Floor(minWeight))
+		}
+	}
+
+	for i := 0; i < vertexCount; i++ {
+		for _, r := range []int{reduction.RowReductions[i], reduction.ColumnReductions[i]} {
+			if addOverflows(reduction.Offset, r) {
+				return MatrixReduction{}, ErrPathWeightOverflow
+			}
+			reduction.Offset += r
+		}
+	}
+
+	noEdgeValue := target.GetNoEdgeValue()
+	edges := make([]Edge, 0, g.GetEdgeCount())
+	for _, edge := range g.GetAllEdges() {
+		i, j := edge.StartVertex, edge.EndVertex
+		if i == j {
+			continue
+		}
+		weight := edge.Weight - reduction.RowReductions[i] - reduction.ColumnReductions[j]
+		if weight == noEdgeValue {
+			return MatrixReduction{}, fmt.Errorf("zredukowana waga krawędzi %d -> %d jest równa noEdgeValue grafu docelowego (%d)", i, j, noEdgeValue)
+		}
+		edges = append(edges, Edge{StartVertex: i, EndVertex: j, Weight: weight})
+	}
+
+	metadata := GetGraphMetadata(g)
+	metadata.Comment = fmt.Sprintf("Graf po redukcji wierszy i kolumn (offset %d)", reduction.Offset)
+	var coordinates []Coordinate
+	if cg, ok := g.(CoordinateGraph); ok {
+		coordinates = cg.GetCoordinates()
+	}
+	if err := storeEdges(target, vertexCount, edges, coordinates, metadata); err != nil {
+		return MatrixReduction{}, err
+	}
+
+	if floatWeights {
+		setter := target.(floatWeightSetter)
+		for _, edge := range edges {
+			i, j := edge.StartVertex, edge.EndVertex
+			setter.setWeightFloat(i, j, weights[i][j]-float64(reduction.RowReductions[i]+reduction.ColumnReductions[j]))
+		}
+	}
+	return reduction, nil
+}
+
+// OriginalCost przelicza koszt trasy w grafie zredukowanym na koszt w grafie oryginalnym
+func (r MatrixReduction) OriginalCost(reducedCost int) int {
+	return reducedCost + r.Offset
+}
+
+// OriginalCostFloat działa jak OriginalCost dla kosztów zmiennoprzecinkowych
+func (r MatrixReduction) OriginalCostFloat(reducedCost float64) float64 {
+	return reducedCost + float64(r.Offset)
+}
@@ -0,0 +1,145 @@
+package graph_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"projekt2/graph"
+)
+
+// matrixTestGraph zapisuje macierz w grafie; wartość -1 oznacza brak krawędzi
+func matrixTestGraph(g graph.MatrixGraph, matrix [][]int) graph.MatrixGraph {
+	for i, row := range matrix {
+		for j, weight := range row {
+			if weight != -1 {
+				g.AddEdge(i, j, weight)
+			}
+		}
+	}
+	return g
+}
+
+// forEachTour wywołuje visit dla każdej trasy zamkniętej zaczynającej się w wierzchołku 0
+func forEachTour(vertexCount int, visit func(tour []int)) {
+	rest := make([]int, 0, vertexCount-1)
+	for v := 1; v < vertexCount; v++ {
+		rest = append(rest, v)
+	}
+	var permute func(k int)
+	permute = func(k int) {
+		if k == len(rest) {
+			visit(append(append([]int{0}, rest...), 0))
+			return
+		}
+		for i := k; i < len(rest); i++ {
+			rest[k], rest[i] = rest[i], rest[k]
+			permute(k + 1)
+			rest[k], rest[i] = rest[i], rest[k]
+		}
+	}
+	permute(0)
+}
+
+func TestReduceGraph(t *testing.T) {
+	handMatrix := [][]int{
+		{-1, 10, 20, 30},
+		{5, -1, 8, 12},
+		{9, 4, -1, 6},
+		{7, 11, 3, -1},
+	}
+
+	tests := []struct {
+		name             string
+		source           graph.MatrixGraph
+		target           graph.MatrixGraph
+		rowReductions    []int // nil - bez sprawdzania konkretnych wartości
+		columnReductions []int
+	}{
+		{"macierz liczona ręcznie", matrixTestGraph(graph.NewAdjMatrixGraph(4, -1), handMatrix), graph.NewAdjMatrixGraph(0, -1),
+			[]int{10, 5, 4, 3}, []int{0, 0, 0, 2}},
+		{"pełny graf", asymmetricTestGraph(graph.NewAdjMatrixGraph(6, -1), 6, nil), graph.NewAdjMatrixGraph(0, -1), nil, nil},
+		{"graf niepełny", asymmetricTestGraph(graph.NewAdjMatrixGraph(6, -1), 6, [][2]int{{0, 2}, {2, 0}, {3, 1}, {4, 0}, {5, 4}}),
+			graph.NewAdjMatrixGraph(0, math.MaxInt), nil, nil},
+		{"listy sąsiedztwa", asymmetricTestGraph(graph.NewAdjListGraph(5, -1), 5, [][2]int{{1, 3}, {3, 4}}),
+			graph.NewAdjListGraph(0, -1), nil, nil},
+		{"wagi niecałkowite", func() graph.MatrixGraph {
+			g := graph.NewFloatMatrixGraph(4, -1)
+			for i, row := range handMatrix {
+				for j, weight := range row {
+					if weight != -1 {
+						g.AddEdgeFloat(i, j, float64(weight)+0.5)
+					}
+				}
+			}
+			return g
+		}(), graph.NewFloatMatrixGraph(0, -1), []int{10, 5, 4, 3}, []int{0, 0, 0, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := tt.source.GetVertexCount()
+			reduction, err := graph.ReduceGraph(tt.source, tt.target)
+			if err != nil {
+				t.Fatalf("ReduceGraph: %v", err)
+			}
+			if tt.rowReductions != nil && (!slices.Equal(reduction.RowReductions, tt.rowReductions) ||
+				!slices.Equal(reduction.ColumnReductions, tt.columnReductions)) {
+				t.Errorf("redukcje wierszy %v i kolumn %v, oczekiwano %v i %v",
+					reduction.RowReductions, reduction.ColumnReductions, tt.rowReductions, tt.columnReductions)
+			}
+			offset := 0
+			for v := 0; v < n; v++ {
+				offset += reduction.RowReductions[v] + reduction.ColumnReductions[v]
+			}
+			if reduction.Offset != offset {
+				t.Errorf("offset %d, oczekiwano sumy redukcji %d", reduction.Offset, offset)
+			}
+
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					if tt.target.IsAdjacent(i, j) != (i != j && tt.source.IsAdjacent(i, j)) {
+						t.Fatalf("krawędź %d -> %d: istnienie w grafie zredukowanym różni się od oryginału", i, j)
+					}
+					if tt.target.IsAdjacent(i, j) && graph.EdgeWeightFloat(tt.target, i, j) < 0 {
+						t.Errorf("ujemna zredukowana waga %d -> %d", i, j)
+					}
+				}
+			}
+
+			// Koszt każdej trasy dopuszczalnej jest mniejszy dokładnie o Offset
+			feasibleTours := 0
+			forEachTour(n, func(tour []int) {
+				originalCost, err := graph.CalculatePathWeightChecked[float64](tt.source, tour)
+				if err != nil {
+					if _, err := graph.CalculatePathWeightChecked[float64](tt.target, tour); err == nil {
+						t.Errorf("trasa %v niedopuszczalna w oryginale jest dopuszczalna po redukcji", tour)
+					}
+					return
+				}
+				feasibleTours++
+				reducedCost, err := graph.CalculatePathWeightChecked[float64](tt.target, tour)
+				if err != nil {
+					t.Fatalf("trasa %v niedopuszczalna po redukcji: %v", tour, err)
+				}
+				if math.Abs(reduction.OriginalCostFloat(reducedCost)-originalCost) > 1e-9 {
+					t.Errorf("trasa %v: koszt zredukowany %v + offset %d != %v", tour, reducedCost, reduction.Offset, originalCost)
+				}
+				if originalCost < float64(reduction.Offset) {
+					t.Errorf("offset %d większy od kosztu trasy %v (%v)", reduction.Offset, tour, originalCost)
+				}
+			})
+			if feasibleTours == 0 {
+				t.Fatal("graf testowy nie ma trasy dopuszczalnej")
+			}
+		})
+	}
+}
+
+func TestReduceGraphNoEdgeValueCollision(t *testing.T) {
+	source := asymmetricTestGraph(graph.NewAdjMatrixGraph(4, -1), 4, nil)
+	// Redukcja zawsze tworzy wagę 0 w każdym wierszu
+	if _, err := graph.ReduceGraph(source, graph.NewAdjMatrixGraph(0, 0)); err == nil {
+		t.Error("oczekiwano błędu, gdy zredukowana waga jest równa noEdgeValue grafu docelowego")
+	}
+}
@@ -0,0 +1,25 @@
+package graph
+
+// Metadata przechowuje opisowe informacje o instancji (np. z nagłówka pliku TSPLIB)
+type Metadata struct {
+	Name           string
+	Comment        string
+	VertexLabels   []string // Oryginalne identyfikatory wierzchołków (np. etykiety z pliku CSV), indeksowane numerem wierzchołka
+	Seed           int64    // Ziarno generatora, którym wygenerowano instancję (0 - instancja wczytana lub ziarno nieznane)
+	EdgeWeightType string   // EDGE_WEIGHT_TYPE wczytanej instancji TSPLIB (np. GEO); puste dla instancji z innych źródeł
+}
+
+// MetadataGraph jest implementowany przez grafy, które pamiętają metadane instancji
+type MetadataGraph interface {
+	Graph
+	GetMetadata() Metadata
+	SetMetadata(metadata Metadata)
+}
+
+// GetGraphMetadata zwraca metadane grafu lub pustą strukturę, jeśli graf ich nie przechowuje
+func GetGraphMetadata(g Graph) Metadata {
+	if mg, ok := g.(MetadataGraph); ok {
+		return mg.GetMetadata()
+	}
+	return Metadata{}
+}
@@ -2,33 +2,69 @@ package graph
 
 import (
 	"math/rand"
+	"strconv"
 	"time"
 )
 
+// NewSeed zwraca nowe ziarno generatora liczb losowych oparte na bieżącym czasie (zawsze różne od 0)
+func NewSeed() int64 {
+	seed := time.Now().UnixNano()
+	if seed == 0 {
+		seed = 1
+	}
+	return seed
+}
+
+// newSeededRand tworzy lokalny generator liczb losowych o podanym ziarnie, aby nie modyfikować globalnego stanu pakietu rand.
+// Ten sam seed daje zawsze te same instancje.
+func newSeededRand(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
+
+// seedComment zwraca komentarz instancji wygenerowanej z podanym ziarnem
+func seedComment(description string, seed int64) string {
+	return description + " (ziarno " + strconv.FormatInt(seed, 10) + ")"
+}
+
 // GenerateRandomGraph generuje losowy graf z daną liczbą wierzchołków i wypełnia krawędzie losowymi wagami większymi niż zero.
 // Wartość `noEdgeValue` jest przypisywana tam, gdzie krawędź nie istnieje (między wierzchołkiem a samym sobą).
 // `maxWeight` - maksymalna wartość wag krawędzi (losowane wartości będą z zakresu od 1 do maxWeight).
-func GenerateRandomGraph(g *AdjMatrixGraph, vertexCount int, noEdgeValue int, maxWeight int) {
-	// Inicjalizuj licznik losowy za pomocą obecnego czasu
-	rand.Seed(time.Now().UnixNano())
+// Ziarno jest wybierane na podstawie czasu, zapisywane w metadanych grafu i zwracane, aby można było odtworzyć instancję
+// funkcją GenerateRandomGraphWithSeed.
+func GenerateRandomGraph(g MatrixGraph, vertexCount int, noEdgeValue int, maxWeight int) int64 {
+	seed := NewSeed()
+	GenerateRandomGraphWithSeed(g, vertexCount, noEdgeValue, maxWeight, seed)
+	return seed
+}
+
+// GenerateRandomGraphWithSeed działa jak GenerateRandomGraph, ale używa podanego ziarna
+func GenerateRandomGraphWithSeed(g MatrixGraph, vertexCount int, noEdgeValue int, maxWeight int, seed int64) {
+	GenerateRandomGraphWithRand(g, vertexCount, noEdgeValue, maxWeight, newSeededRand(seed))
+	g.SetMetadata(Metadata{
+		Name:    "rand" + strconv.Itoa(vertexCount),
+		Comment: seedComment("Losowy graf", seed),
+		Seed:    seed,
+	})
+}
 
+// GenerateRandomGraphWithRand działa jak GenerateRandomGraph, ale losuje wagi z podanego generatora rng.
+// Pozwala generować grafy równolegle (każdy wątek z własnym generatorem). Ziarno nie jest znane, więc nie trafia do metadanych.
+func GenerateRandomGraphWithRand(g MatrixGraph, vertexCount int, noEdgeValue int, maxWeight int, rng *rand.Rand) {
 	// Ustaw liczbę wierzchołków i inicjalizuj macierz sąsiedztwa
-	g.vertexCount = vertexCount
-	g.noEdgeValue = noEdgeValue
-	g.adjMatrix = make([][]int, vertexCount)
-	for i := 0; i < vertexCount; i++ {
-		g.adjMatrix[i] = make([]int, vertexCount)
-	}
+	g.SetNoEdgeValue(noEdgeValue)
+	g.SetCoordinates(nil)
+	g.SetMetadata(Metadata{})
+	g.resetMatrix(vertexCount)
 
 	// Przejdź przez wszystkie pary wierzchołków i generuj losowe wagi
 	for i := 0; i < vertexCount; i++ {
 		for j := 0; j < vertexCount; j++ {
 			if i == j {
 				// Brak krawędzi do samego siebie
-				g.adjMatrix[i][j] = noEdgeValue
+				g.setWeight(i, j, noEdgeValue)
 			} else {
 				// Generowanie losowej wagi krawędzi większej niż 0
-				g.adjMatrix[i][j] = rand.Intn(maxWeight) + 1 // Losowe liczby od 1 do maxWeight
+				g.setWeight(i, j, rng.Intn(maxWeight)+1) // Losowe liczby od 1 do maxWeight
 			}
 		}
 	}
@@ -0,0 +1,117 @@
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// sameWeights sprawdza, czy grafy mają te same krawędzie i wagi (bez przerywania testu)
+func sameWeights(a, b Graph) bool {
+	if a.GetVertexCount() != b.GetVertexCount() {
+		return false
+	}
+	for i := 0; i < a.GetVertexCount(); i++ {
+		for j := 0; j < a.GetVertexCount(); j++ {
+			if a.IsAdjacent(i, j) != b.IsAdjacent(i, j) || (a.IsAdjacent(i, j) && EdgeWeightFloat(a, i, j) != EdgeWeightFloat(b, i, j)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// checkGeneratorSeeds sprawdza, że generator z tym samym ziarnem daje tę samą instancję (wagi i współrzędne),
+// z innym ziarnem inną, a ziarno trafia do metadanych
+func checkGeneratorSeeds(t *testing.T, tests []generatorTest) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graphs := make(map[int64]MatrixGraph)
+			for _, seed := range []int64{7, 8} {
+				graphs[seed] = NewAdjMatrixGraph(0, -1)
+				if err := tt.generate(graphs[seed], -1, seed); err != nil {
+					t.Fatal(err)
+				}
+			}
+			again := NewAdjMatrixGraph(0, -1)
+			if err := tt.generate(again, -1, 7); err != nil {
+				t.Fatal(err)
+			}
+
+			assertSameWeights(t, again, graphs[7])
+			if !reflect.DeepEqual(again.GetCoordinates(), graphs[7].GetCoordinates()) {
+				t.Error("to samo ziarno dało inne współrzędne")
+			}
+			if sameWeights(graphs[8], graphs[7]) {
+				t.Error("ziarna 7 i 8 dały ten sam graf")
+			}
+			metadata := graphs[7].GetMetadata()
+			if metadata.Seed != 7 || !strings.Contains(metadata.Comment, "ziarno 7") {
+				t.Errorf("metadane %+v nie zawierają ziarna 7", metadata)
+			}
+		})
+	}
+}
+
+func TestGenerateRandomGraphWithSeed(t *testing.T) {
+	const vertexCount, maxWeight = 8, 10
+	want := NewAdjMatrixGraph(0, -1)
+	GenerateRandomGraphWithSeed(want, vertexCount, -1, maxWeight, 42)
+
+	// noEdgeValue 0 leży poza zakresem wag 1..maxWeight, więc graf pozostaje pełny
+	for _, noEdgeValue := range []int{-1, 0} {
+		for _, representation := range testRepresentations {
+			t.Run(representation+"/noEdgeValue="+strconv.Itoa(noEdgeValue), func(t *testing.T) {
+				got := newTestGraph(t, representation, 100)
+				GenerateRandomGraphWithSeed(got, vertexCount, noEdgeValue, maxWeight, 42)
+				if got.GetNoEdgeValue() != noEdgeValue || !IsCompleteGraph(got) {
+					t.Fatalf("noEdgeValue %d, pełny: %t", got.GetNoEdgeValue(), IsCompleteGraph(got))
+				}
+				for i := 0; i < vertexCount; i++ {
+					for j := 0; j < vertexCount; j++ {
+						if i == j {
+							continue
+						}
+						weight := got.GetEdge(i, j).Weight
+						if weight < 1 || weight > maxWeight {
+							t.Errorf("waga %d -> %d = %d poza zakresem 1..%d", i, j, weight, maxWeight)
+						}
+						if weight != want.GetEdge(i, j).Weight {
+							t.Errorf("waga %d -> %d = %d, oczekiwano %d jak dla ADJ_MATRIX", i, j, weight, want.GetEdge(i, j).Weight)
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestRandomGraphSeeds(t *testing.T) {
+	checkGeneratorSeeds(t, []generatorTest{
+		{"rand", func(g MatrixGraph, noEdgeValue int, seed int64) error {
+			GenerateRandomGraphWithSeed(g, 8, noEdgeValue, 100, seed)
+			return nil
+		}},
+	})
+}
+
+func TestGenerateRandomGraphReproducible(t *testing.T) {
+	g := NewAdjMatrixGraph(0, -1)
+	seed := GenerateRandomGraph(g, 8, -1, 100)
+	if g.GetMetadata().Seed != seed {
+		t.Errorf("ziarno w metadanych %d, zwrócone %d", g.GetMetadata().Seed, seed)
+	}
+	again := NewAdjMatrixGraph(0, -1)
+	GenerateRandomGraphWithSeed(again, 8, -1, 100, seed)
+	assertSameWeights(t, again, g)
+
+	// Generator rng o tym samym ziarnie daje te same wagi, ale bez ziarna w metadanych
+	fromRand := NewFlatMatrixGraph(0, -1, CellWidth32)
+	GenerateRandomGraphWithRand(fromRand, 8, -1, 100, rand.New(rand.NewSource(seed)))
+	assertSameWeights(t, fromRand, g)
+	if !reflect.DeepEqual(fromRand.GetMetadata(), Metadata{}) {
+		t.Errorf("metadane %+v, oczekiwano pustych", fromRand.GetMetadata())
+	}
+}
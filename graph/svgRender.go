@@ -0,0 +1,173 @@
+package graph
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strconv"
+)
+
+// SVGTour to trasa do narysowania (np. ścieżka zwrócona przez ATSPSolver.Solve) z podpisem panelu
+type SVGTour struct {
+	Title string
+	Path  []int
+}
+
+// SVGOptions określa wygląd rysunku SVG
+type SVGOptions struct {
+	PanelSize  int  // Szerokość i wysokość panelu jednej trasy w pikselach (domyślnie 600)
+	ShowLabels bool // Podpisuje wierzchołki etykietami z metadanych lub numerami
+}
+
+// Kolory kolejnych paneli
+var svgTourColors = []string{"#1f77b4", "#d62728", "#2ca02c", "#9467bd"}
+
+const (
+	svgMargin        = 20.0 // Margines wokół obszaru rysunku
+	svgCaptionHeight = 50.0 // Wysokość podpisu nad obszarem rysunku
+)
+
+// SaveToursToSVGFile rysuje trasy do pliku SVG (szczegóły w WriteToursSVG)
+func SaveToursToSVGFile(g Graph, filePath string, tours []SVGTour, options SVGOptions) error {
+	file, err := createOutputFile(filePath)
+	if err != nil {
+		return err
+	}
+	if err := WriteToursSVG(file, g, tours, options); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// WriteToursSVG zapisuje samodzielny dokument SVG z trasami narysowanymi obok siebie na współrzędnych grafu.
+// Każdy panel pokazuje wierzchołki, łuki trasy ze strzałkami w kierunku przejścia, wyróżniony wierzchołek
+// startowy oraz podpis z kosztem trasy. Łuki nieistniejące w grafie są przerywane.
+// Współrzędne są traktowane jako płaskie (x, y), z wyjątkiem instancji GEO (Metadata.EdgeWeightType).
+// Zwraca błąd, jeśli graf nie ma współrzędnych, nie podano trasy lub trasa zawiera wierzchołek spoza grafu.
+func WriteToursSVG(w io.Writer, g Graph, tours []SVGTour, options SVGOptions) error {
+	vertexCount := g.GetVertexCount()
+	cg, ok := g.(CoordinateGraph)
+	if !ok || len(cg.GetCoordinates()) != vertexCount || vertexCount == 0 {
+		return errors.New("graf nie ma współrzędnych wierzchołków")
+	}
+	if len(tours) == 0 {
+		return errors.New("brak trasy do narysowania")
+	}
+	for _, tour := range tours {
+		if len(tour.Path) == 0 {
+			return fmt.Errorf("trasa %q jest pusta", tour.Title)
+		}
+		for _, v := range tour.Path {
+			if v < 0 || v >= vertexCount {
+				return fmt.Errorf("wierzchołek trasy %d poza zakresem grafu", v)
+			}
+		}
+	}
+	panelSize := float64(options.PanelSize)
+	if panelSize <= 0 {
+		panelSize = 600
+	}
+
+	// Skalowanie z zachowaniem proporcji; oś Y jest odwrócona, bo w SVG rośnie w dół.
+	// Instancje GEO przechowują (szerokość, długość geograficzną), więc osie są zamieniane:
+	// długość na osi X, szerokość na osi Y (północ u góry).
+	coordinates := cg.GetCoordinates()
+	if GetGraphMetadata(g).EdgeWeightType == EdgeWeightTypeGeo {
+		swapped := make([]Coordinate, len(coordinates))
+		for i, c := range coordinates {
+			swapped[i] = Coordinate{X: c.Y, Y: c.X}
+		}
+		coordinates = swapped
+	}
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, c := range coordinates {
+		minX, maxX = math.Min(minX, c.X), math.Max(maxX, c.X)
+		minY, maxY = math.Min(minY, c.Y), math.Max(maxY, c.Y)
+	}
+	drawWidth := panelSize - 2*svgMargin
+	drawHeight := panelSize - 2*svgMargin - svgCaptionHeight
+	scale := math.Min(drawWidth/math.Max(maxX-minX, 1e-9), drawHeight/math.Max(maxY-minY, 1e-9))
+	offsetX := svgMargin + (drawWidth-(maxX-minX)*scale)/2
+	offsetY := svgMargin + svgCaptionHeight + (drawHeight-(maxY-minY)*scale)/2
+	points := make([]Coordinate, vertexCount)
+	for i, c := range coordinates {
+		points[i] = Coordinate{X: offsetX + (c.X-minX)*scale, Y: offsetY + (maxY-c.Y)*scale}
+	}
+	radius := math.Max(1.5, math.Min(5, 150/math.Sqrt(float64(vertexCount))/2))
+
+	metadata := GetGraphMetadata(g)
+	vertexLabel := func(v int) string {
+		if len(metadata.VertexLabels) == vertexCount {
+			return metadata.VertexLabels[v]
+		}
+		return strconv.Itoa(v)
+	}
+	number := func(x float64) string {
+		return strconv.FormatFloat(x, 'f', 2, 64)
+	}
+
+	out := bufio.NewWriter(w)
+	width := panelSize * float64(len(tours))
+	out.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	out.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" width="` + number(width) + `" height="` + number(panelSize) +
+		`" viewBox="0 0 ` + number(width) + " " + number(panelSize) + `" font-family="sans-serif">` + "\n")
+	out.WriteString("<defs>\n")
+	for k := range tours {
+		color := svgTourColors[k%len(svgTourColors)]
+		out.WriteString(`<marker id="arrow` + strconv.Itoa(k) + `" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="6" markerHeight="6" orient="auto">` +
+			`<path d="M 0 0 L 10 5 L 0 10 z" fill="` + color + `"/></marker>` + "\n")
+	}
+	out.WriteString("</defs>\n")
+	out.WriteString(`<rect width="100%" height="100%" fill="white"/>` + "\n")
+
+	for k, tour := range tours {
+		color := svgTourColors[k%len(svgTourColors)]
+		closedTour := openTour(tour.Path)
+		closedTour = append(closedTour, closedTour[0])
+
+		out.WriteString(`<g transform="translate(` + number(panelSize*float64(k)) + `,0)">` + "\n")
+		out.WriteString(`<rect x="0.5" y="0.5" width="` + number(panelSize-1) + `" height="` + number(panelSize-1) + `" fill="none" stroke="#cccccc"/>` + "\n")
+		out.WriteString(`<text x="` + number(svgMargin) + `" y="` + number(svgMargin+5) + `" font-size="16" font-weight="bold">` +
+			html.EscapeString(tour.Title) + "</text>\n")
+		out.WriteString(`<text x="` + number(svgMargin) + `" y="` + number(svgMargin+27) + `" font-size="14">` +
+			html.EscapeString("Koszt: "+FormatPathWeight(g, closedTour)+", wierzchołków: "+strconv.Itoa(len(closedTour)-1)) + "</text>\n")
+
+		// Łuki skrócone o promień wierzchołka, aby groty strzałek były widoczne
+		for i := 0; i < len(closedTour)-1; i++ {
+			from, to := closedTour[i], closedTour[i+1]
+			start, end := points[from], points[to]
+			dx, dy := end.X-start.X, end.Y-start.Y
+			length := math.Hypot(dx, dy)
+			if length <= 2*radius {
+				continue
+			}
+			ux, uy := dx/length, dy/length
+			dash := ""
+			if !g.IsAdjacent(from, to) {
+				dash = ` stroke-dasharray="4,3"`
+			}
+			out.WriteString(`<line x1="` + number(start.X+ux*radius) + `" y1="` + number(start.Y+uy*radius) +
+				`" x2="` + number(end.X-ux*(radius+1)) + `" y2="` + number(end.Y-uy*(radius+1)) +
+				`" stroke="` + color + `" stroke-width="1.5"` + dash + ` marker-end="url(#arrow` + strconv.Itoa(k) + `)"/>` + "\n")
+		}
+
+		for v, p := range points {
+			out.WriteString(`<circle cx="` + number(p.X) + `" cy="` + number(p.Y) + `" r="` + number(radius) + `" fill="#333333"/>` + "\n")
+			if options.ShowLabels {
+				out.WriteString(`<text x="` + number(p.X+radius+2) + `" y="` + number(p.Y-radius-2) + `" font-size="10">` +
+					html.EscapeString(vertexLabel(v)) + "</text>\n")
+			}
+		}
+		start := points[closedTour[0]]
+		out.WriteString(`<circle cx="` + number(start.X) + `" cy="` + number(start.Y) + `" r="` + number(2*radius) +
+			`" fill="none" stroke="` + color + `" stroke-width="2.5"><title>Start: ` + html.EscapeString(vertexLabel(closedTour[0])) + "</title></circle>\n")
+		out.WriteString("</g>\n")
+	}
+	out.WriteString("</svg>\n")
+	return out.Flush()
+}
@@ -0,0 +1,64 @@
+package graph
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestWriteToursSVGAxes(t *testing.T) {
+	// Wierzchołki: 0 = (10, 0), 1 = (10, 50), 2 = (20, 25)
+	tests := []struct {
+		edgeWeightType string
+		rightmost      int // Wierzchołek o największym cx
+		topmost        int // Wierzchołek o najmniejszym cy
+	}{
+		{EdgeWeightTypeEuc2D, 2, 1},
+		{EdgeWeightTypeAtt, 2, 1},
+		{EdgeWeightTypeGeo, 1, 2}, // (szerokość, długość): długość na osi X, szerokość na osi Y
+	}
+
+	circle := regexp.MustCompile(`<circle cx="([0-9.]+)" cy="([0-9.]+)" r="[0-9.]+" fill="#333333"/>`)
+	for _, tt := range tests {
+		t.Run(tt.edgeWeightType, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "axes.tsp")
+			content := "NAME: axes\nTYPE: TSP\nDIMENSION: 3\nEDGE_WEIGHT_TYPE: " + tt.edgeWeightType +
+				"\nNODE_COORD_SECTION\n1 10 0\n2 10 50\n3 20 25\nEOF\n"
+			if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			g := NewAdjMatrixGraph(0, -1)
+			if _, err := LoadGraphFromFile(filePath, g); err != nil {
+				t.Fatalf("LoadGraphFromFile: %v", err)
+			}
+
+			var out bytes.Buffer
+			if err := WriteToursSVG(&out, g, []SVGTour{{Title: "trasa", Path: []int{0, 1, 2, 0}}}, SVGOptions{}); err != nil {
+				t.Fatalf("WriteToursSVG: %v", err)
+			}
+			matches := circle.FindAllStringSubmatch(out.String(), -1)
+			if len(matches) != 3 {
+				t.Fatalf("oczekiwano 3 wierzchołków, znaleziono %d", len(matches))
+			}
+			rightmost, topmost := 0, 0
+			for v, m := range matches {
+				x, _ := strconv.ParseFloat(m[1], 64)
+				y, _ := strconv.ParseFloat(m[2], 64)
+				bestX, _ := strconv.ParseFloat(matches[rightmost][1], 64)
+				bestY, _ := strconv.ParseFloat(matches[topmost][2], 64)
+				if x > bestX {
+					rightmost = v
+				}
+				if y < bestY {
+					topmost = v
+				}
+			}
+			if rightmost != tt.rightmost || topmost != tt.topmost {
+				t.Errorf("skrajny prawy %d, górny %d; oczekiwano %d, %d", rightmost, topmost, tt.rightmost, tt.topmost)
+			}
+		})
+	}
+}
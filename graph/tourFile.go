@@ -0,0 +1,109 @@
+package graph
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Tour to trasa odczytana z pliku .tour w formacie TSPLIB
+type Tour struct {
+	Name    string
+	Comment string
+	Path    []int // Zamknięta ścieżka jak w wynikach solverów: indeksy od 0, ostatni wierzchołek równy pierwszemu
+}
+
+// SaveTourToFile zapisuje ścieżkę zwróconą przez solver do pliku .tour (TYPE: TOUR, dla rozszerzenia .gz z kompresją gzip).
+// Ścieżka może kończyć się powrotem do wierzchołka startowego - jest on wtedy pomijany,
+// bo TOUR_SECTION zawiera każdy wierzchołek dokładnie raz (numeracja od 1) i kończy się wartością -1.
+func SaveTourToFile(filePath string, path []int, name, comment string) error {
+	tour := openTour(path)
+	if len(tour) == 0 {
+		return errors.New("brak trasy do zapisania")
+	}
+
+	file, err := createOutputFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	out := bufio.NewWriter(file)
+	if name == "" {
+		name = "tour" + strconv.Itoa(len(tour))
+	}
+	out.WriteString("NAME: " + name + "\n")
+	out.WriteString("TYPE: TOUR\n")
+	if comment != "" {
+		out.WriteString("COMMENT: " + comment + "\n")
+	}
+	out.WriteString("DIMENSION: " + strconv.Itoa(len(tour)) + "\n")
+	out.WriteString("TOUR_SECTION\n")
+	for _, vertex := range tour {
+		out.WriteString(strconv.Itoa(vertex+1) + "\n")
+	}
+	out.WriteString("-1\nEOF\n")
+
+	if err := out.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// LoadTourFromFile wczytuje trasę z pliku .tour lub .opt.tour (również skompresowanego gzip lub bzip2).
+// Zwrócona ścieżka jest zamknięta (kończy się wierzchołkiem startowym), tak jak ścieżki solverów.
+func LoadTourFromFile(filePath string) (Tour, error) {
+	file, err := openInputFile(filePath)
+	if err != nil {
+		return Tour{}, err
+	}
+	defer file.Close()
+
+	instance, err := parseTSPLIB(newLineScanner(file))
+	if err != nil {
+		return Tour{}, err
+	}
+	if len(instance.tour) == 0 {
+		return Tour{}, errors.New("brak sekcji TOUR_SECTION w pliku")
+	}
+	if instance.dimension != 0 && instance.dimension != len(instance.tour) {
+		return Tour{}, fmt.Errorf("trasa zawiera %d wierzchołków, a DIMENSION wynosi %d", len(instance.tour), instance.dimension)
+	}
+
+	path := append(instance.tour, instance.tour[0])
+	return Tour{Name: instance.name, Comment: instance.comment, Path: path}, nil
+}
+
+// EvaluateTour sprawdza, czy ścieżka jest cyklem Hamiltona w grafie i zwraca jej koszt (CalculatePathWeightChecked),
+// zgłaszając błąd dla nieistniejącej krawędzi lub przepełnienia.
+// Akceptowane są zarówno ścieżki zamknięte (z powrotem do startu), jak i otwarte jak w pliku .tour.
+func EvaluateTour(g Graph, path []int) (int, error) {
+	tour := openTour(path)
+	if len(tour) != g.GetVertexCount() {
+		return 0, fmt.Errorf("trasa zawiera %d wierzchołków, a graf ma ich %d", len(tour), g.GetVertexCount())
+	}
+
+	visited := make([]bool, g.GetVertexCount())
+	for _, vertex := range tour {
+		if vertex < 0 || vertex >= g.GetVertexCount() {
+			return 0, fmt.Errorf("wierzchołek %d poza zakresem grafu", vertex)
+		}
+		if visited[vertex] {
+			return 0, fmt.Errorf("wierzchołek %d występuje w trasie więcej niż raz", vertex)
+		}
+		visited[vertex] = true
+	}
+
+	return CalculatePathWeightChecked[int](g, append(tour, tour[0]))
+}
+
+// openTour zwraca kopię ścieżki bez powtórzonego na końcu wierzchołka startowego
+func openTour(path []int) []int {
+	tour := make([]int, len(path))
+	copy(tour, path)
+	if len(tour) > 1 && tour[0] == tour[len(tour)-1] {
+		tour = tour[:len(tour)-1]
+	}
+	return tour
+}
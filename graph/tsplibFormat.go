@@ -0,0 +1,392 @@
+package graph
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Formaty zapisu wag krawędzi (EDGE_WEIGHT_FORMAT) zdefiniowane w TSPLIB
+const (
+	EdgeWeightFormatFullMatrix   = "FULL_MATRIX"
+	EdgeWeightFormatUpperRow     = "UPPER_ROW"
+	EdgeWeightFormatLowerRow     = "LOWER_ROW"
+	EdgeWeightFormatUpperDiagRow = "UPPER_DIAG_ROW"
+	EdgeWeightFormatLowerDiagRow = "LOWER_DIAG_ROW"
+	EdgeWeightFormatUpperCol     = "UPPER_COL"
+	EdgeWeightFormatLowerCol     = "LOWER_COL"
+	EdgeWeightFormatUpperDiagCol = "UPPER_DIAG_COL"
+	EdgeWeightFormatLowerDiagCol = "LOWER_DIAG_COL"
+)
+
+// TSPLIBSaveOptions określa sposób zapisu grafu w formacie TSPLIB
+type TSPLIBSaveOptions struct {
+	Name             string // Nazwa instancji; pusta oznacza nazwę z metadanych grafu
+	Comment          string // Komentarz; pusty oznacza komentarz z metadanych grafu
+	EdgeWeightFormat string // Układ macierzy; pusty oznacza FULL_MATRIX
+}
+
+// tsplibInstance przechowuje dane odczytane z pliku TSPLIB przed zbudowaniem grafu
+type tsplibInstance struct {
+	name             string
+	problemType      string
+	comment          string
+	dimension        int
+	edgeWeightType   string
+	edgeWeightFormat string
+	edgeWeights      []int        // Wszystkie liczby z sekcji EDGE_WEIGHT_SECTION w kolejności z pliku
+	coordinates      []Coordinate // Współrzędne z sekcji NODE_COORD_SECTION (indeksowane od 0)
+	hasCoordinate    []bool
+	tour             []int // Wierzchołki z sekcji TOUR_SECTION (indeksowane od 0)
+	tourFinished     bool  // Czy sekcja TOUR_SECTION została zakończona wartością -1
+}
+
+// parseTSPLIB odczytuje nagłówek i sekcje pliku TSPLIB.
+// Linie nagłówka mają postać "KLUCZ : WARTOŚĆ" (spacje wokół dwukropka są opcjonalne),
+// a sekcje danych zaczynają się od linii z nazwą sekcji i trwają do następnego słowa kluczowego.
+func parseTSPLIB(scanner *bufio.Scanner) (*tsplibInstance, error) {
+	instance := &tsplibInstance{edgeWeightFormat: EdgeWeightFormatFullMatrix}
+	section := ""
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if line == "EOF" {
+			break
+		}
+
+		// Linie danych należą do aktualnie otwartej sekcji
+		if !isTSPLIBKeywordLine(line) {
+			switch section {
+			case "EDGE_WEIGHT_SECTION":
+				for _, val := range strings.Fields(line) {
+					num, err := strconv.Atoi(val)
+					if err != nil {
+						return nil, errors.New("błąd konwersji wartości w sekcji EDGE_WEIGHT_SECTION")
+					}
+					instance.edgeWeights = append(instance.edgeWeights, num)
+				}
+			case "NODE_COORD_SECTION":
+				if err := instance.parseCoordinateLine(line); err != nil {
+					return nil, err
+				}
+			case "TOUR_SECTION":
+				if err := instance.parseTourLine(line); err != nil {
+					return nil, err
+				}
+			case "":
+				return nil, fmt.Errorf("nieoczekiwana linia poza sekcją danych: %s", line)
+			}
+			// Dane pozostałych sekcji (np. DISPLAY_DATA_SECTION) są pomijane
+			continue
+		}
+
+		key, value := splitTSPLIBHeaderLine(line)
+		if strings.HasSuffix(key, "_SECTION") {
+			if key == "NODE_COORD_SECTION" && instance.dimension == 0 {
+				return nil, errors.New("sekcja NODE_COORD_SECTION przed określeniem wymiaru DIMENSION")
+			}
+			section = key
+			continue
+		}
+		section = ""
+
+		switch key {
+		case "NAME":
+			instance.name = value
+		case "TYPE":
+			instance.problemType = value
+		case "COMMENT":
+			if instance.comment != "" {
+				instance.comment += "\n"
+			}
+			instance.comment += value
+		case "DIMENSION":
+			dimension, err := strconv.Atoi(value)
+			if err != nil || dimension <= 0 {
+				return nil, errors.New("błąd przy konwersji wymiaru z pliku TSPLIB")
+			}
+			instance.dimension = dimension
+		case "EDGE_WEIGHT_TYPE":
+			instance.edgeWeightType = strings.ToUpper(value)
+		case "EDGE_WEIGHT_FORMAT":
+			instance.edgeWeightFormat = strings.ToUpper(value)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	// Pliki .tour z innych narzędzi nie zawsze podają wymiar
+	if instance.dimension == 0 && instance.tour == nil {
+		return nil, errors.New("nie znaleziono wymiaru w pliku TSPLIB")
+	}
+	return instance, nil
+}
+
+// parseCoordinateLine odczytuje linię "numer x y" z sekcji NODE_COORD_SECTION (numeracja od 1)
+func (t *tsplibInstance) parseCoordinateLine(line string) error {
+	values := strings.Fields(line)
+	if len(values) < 3 {
+		return fmt.Errorf("niepełna linia w sekcji NODE_COORD_SECTION: %s", line)
+	}
+	id, err := strconv.Atoi(values[0])
+	if err != nil || id < 1 || id > t.dimension {
+		return fmt.Errorf("nieprawidłowy numer wierzchołka w sekcji NODE_COORD_SECTION: %s", values[0])
+	}
+	x, errX := strconv.ParseFloat(values[1], 64)
+	y, errY := strconv.ParseFloat(values[2], 64)
+	if errX != nil || errY != nil {
+		return fmt.Errorf("błąd konwersji współrzędnych wierzchołka %d", id)
+	}
+
+	if t.coordinates == nil {
+		t.coordinates = make([]Coordinate, t.dimension)
+		t.hasCoordinate = make([]bool, t.dimension)
+	}
+	t.coordinates[id-1] = Coordinate{X: x, Y: y}
+	t.hasCoordinate[id-1] = true
+	return nil
+}
+
+// parseTourLine odczytuje numery wierzchołków z sekcji TOUR_SECTION (numeracja od 1).
+// Wartość -1 kończy trasę; kolejne trasy w tej samej sekcji są pomijane.
+func (t *tsplibInstance) parseTourLine(line string) error {
+	for _, val := range strings.Fields(line) {
+		if t.tourFinished {
+			return nil
+		}
+		id, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("nieprawidłowy numer wierzchołka w sekcji TOUR_SECTION: %s", val)
+		}
+		if id == -1 {
+			t.tourFinished = true
+			continue
+		}
+		if id < 1 {
+			return fmt.Errorf("nieprawidłowy numer wierzchołka w sekcji TOUR_SECTION: %s", val)
+		}
+		t.tour = append(t.tour, id-1)
+	}
+	return nil
+}
+
+// isTSPLIBKeywordLine sprawdza, czy linia zaczyna się od słowa kluczowego (a nie od liczby)
+func isTSPLIBKeywordLine(line string) bool {
+	first := line[0]
+	return (first >= 'A' && first <= 'Z') || (first >= 'a' && first <= 'z')
+}
+
+// splitTSPLIBHeaderLine rozdziela linię nagłówka na klucz i wartość
+func splitTSPLIBHeaderLine(line string) (string, string) {
+	parts := strings.SplitN(line, ":", 2)
+	key := strings.ToUpper(strings.TrimSpace(parts[0]))
+	value := ""
+	if len(parts) == 2 {
+		value = strings.TrimSpace(parts[1])
+	}
+	return key, value
+}
+
+// buildMatrix tworzy macierz sąsiedztwa na podstawie wczytanej instancji.
+// Dla typu EXPLICIT rozwija sekcję EDGE_WEIGHT_SECTION, a dla typów opartych na współrzędnych
+// wylicza odległości z sekcji NODE_COORD_SECTION.
+// Wartość noEdgeValue jest wpisywana na przekątną tam, gdzie plik jej nie określa.
+func (t *tsplibInstance) buildMatrix(noEdgeValue int) ([][]int, error) {
+	if t.edgeWeightType == "" || t.edgeWeightType == EdgeWeightTypeExplicit {
+		return expandEdgeWeights(t.edgeWeightFormat, t.dimension, t.edgeWeights, noEdgeValue)
+	}
+
+	distance, err := t.coordinateDistance()
+	if err != nil {
+		return nil, err
+	}
+	return BuildDistanceMatrix(t.coordinates, distance, noEdgeValue), nil
+}
+
+// coordinateDistance sprawdza kompletność sekcji NODE_COORD_SECTION i zwraca funkcję odległości dla EDGE_WEIGHT_TYPE
+func (t *tsplibInstance) coordinateDistance() (DistanceFunction, error) {
+	distance, err := DistanceFunctionForType(t.edgeWeightType)
+	if err != nil {
+		return nil, err
+	}
+	if t.coordinates == nil {
+		return nil, errors.New("brak sekcji NODE_COORD_SECTION dla typu " + t.edgeWeightType)
+	}
+	for i, ok := range t.hasCoordinate {
+		if !ok {
+			return nil, fmt.Errorf("brak współrzędnych wierzchołka %d w sekcji NODE_COORD_SECTION", i+1)
+		}
+	}
+	return distance, nil
+}
+
+// requiredEdgeWeightCount zwraca liczbę wartości potrzebną do zapisania macierzy n x n w danym formacie
+func requiredEdgeWeightCount(format string, dimension int) (int, error) {
+	switch format {
+	case EdgeWeightFormatFullMatrix:
+		return dimension * dimension, nil
+	case EdgeWeightFormatUpperRow, EdgeWeightFormatLowerRow, EdgeWeightFormatUpperCol, EdgeWeightFormatLowerCol:
+		return dimension * (dimension - 1) / 2, nil
+	case EdgeWeightFormatUpperDiagRow, EdgeWeightFormatLowerDiagRow, EdgeWeightFormatUpperDiagCol, EdgeWeightFormatLowerDiagCol:
+		return dimension * (dimension + 1) / 2, nil
+	}
+	return 0, fmt.Errorf("nieobsługiwany format EDGE_WEIGHT_FORMAT: %s", format)
+}
+
+// edgeWeightRowRange zwraca zakres kolumn [from, to) zapisanych w wierszu i dla danego formatu.
+// Formaty kolumnowe dla macierzy symetrycznej odpowiadają przeciwnemu formatowi wierszowemu,
+// np. UPPER_COL zawiera te same liczby w tej samej kolejności co LOWER_ROW.
+func edgeWeightRowRange(format string, dimension, i int) (int, int) {
+	switch format {
+	case EdgeWeightFormatUpperRow, EdgeWeightFormatLowerCol:
+		return i + 1, dimension
+	case EdgeWeightFormatLowerRow, EdgeWeightFormatUpperCol:
+		return 0, i
+	case EdgeWeightFormatUpperDiagRow, EdgeWeightFormatLowerDiagCol:
+		return i, dimension
+	case EdgeWeightFormatLowerDiagRow, EdgeWeightFormatUpperDiagCol:
+		return 0, i + 1
+	}
+	return 0, dimension
+}
+
+// expandEdgeWeights rozwija wartości z sekcji EDGE_WEIGHT_SECTION do pełnej macierzy n x n.
+// Formaty trójkątne opisują macierz symetryczną, więc każda wartość trafia do komórek [i][j] oraz [j][i].
+// Przekątna formatów *_DIAG_* (zwykle zera) jest zastępowana przez diagonalValue, aby nie tworzyć pętli
+// o wadze 0 - pętla nie należy do żadnej trasy, a jako krawędź osłabiałaby ograniczenia BnB.
+// FULL_MATRIX zachowuje przekątną z pliku, tak jak wcześniej.
+func expandEdgeWeights(format string, dimension int, values []int, diagonalValue int) ([][]int, error) {
+	required, err := requiredEdgeWeightCount(format, dimension)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) < required {
+		return nil, errors.New("zbyt mało danych w sekcji EDGE_WEIGHT_SECTION aby uzupełnić macierz")
+	}
+
+	matrix := make([][]int, dimension)
+	for i := 0; i < dimension; i++ {
+		matrix[i] = make([]int, dimension)
+		matrix[i][i] = diagonalValue
+	}
+
+	idx := 0
+	for i := 0; i < dimension; i++ {
+		from, to := edgeWeightRowRange(format, dimension, i)
+		for j := from; j < to; j++ {
+			matrix[i][j] = values[idx]
+			if format != EdgeWeightFormatFullMatrix {
+				matrix[j][i] = values[idx]
+			}
+			idx++
+		}
+	}
+	if strings.Contains(format, "_DIAG_") {
+		for i := 0; i < dimension; i++ {
+			matrix[i][i] = diagonalValue
+		}
+	}
+
+	return matrix, nil
+}
+
+// isSymmetric sprawdza, czy waga krawędzi i -> j jest równa wadze j -> i dla każdej pary wierzchołków
+func isSymmetric(g Graph) bool {
+	for i := 0; i < g.GetVertexCount(); i++ {
+		for j := i + 1; j < g.GetVertexCount(); j++ {
+			if g.GetEdge(i, j).Weight != g.GetEdge(j, i).Weight {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// checkIntegerWeights zwraca błąd, jeśli graf FloatGraph ma krawędź o wadze niecałkowitej
+func checkIntegerWeights(g Graph) error {
+	fg, ok := g.(FloatGraph)
+	if !ok {
+		return nil
+	}
+	for i := 0; i < fg.GetVertexCount(); i++ {
+		for j := 0; j < fg.GetVertexCount(); j++ {
+			if weight := fg.GetEdgeWeightFloat(i, j); fg.IsAdjacent(i, j) && weight != math.Trunc(weight) {
+				return fmt.Errorf("waga krawędzi %d -> %d (%s) nie jest liczbą całkowitą - zapisz graf w formacie macierzowym, JSON lub CSV",
+					i, j, FormatWeight(weight))
+			}
+		}
+	}
+	return nil
+}
+
+// writeTSPLIB zapisuje graf w formacie TSPLIB.
+// Typ problemu to TSP dla macierzy symetrycznej i ATSP w przeciwnym wypadku.
+// Układy trójkątne mogą opisać tylko macierz symetryczną.
+// Wagi muszą być całkowite (patrz checkIntegerWeights).
+func writeTSPLIB(w io.Writer, g Graph, options TSPLIBSaveOptions) error {
+	format := strings.ToUpper(options.EdgeWeightFormat)
+	if format == "" {
+		format = EdgeWeightFormatFullMatrix
+	}
+	if _, err := requiredEdgeWeightCount(format, g.GetVertexCount()); err != nil {
+		return err
+	}
+
+	problemType := "ATSP"
+	if isSymmetric(g) {
+		problemType = "TSP"
+	} else if format != EdgeWeightFormatFullMatrix {
+		return errors.New("graf asymetryczny można zapisać tylko w formacie FULL_MATRIX")
+	}
+
+	metadata := GetGraphMetadata(g)
+	name := options.Name
+	if name == "" {
+		name = metadata.Name
+	}
+	if name == "" {
+		name = "graph" + strconv.Itoa(g.GetVertexCount())
+	}
+	comment := options.Comment
+	if comment == "" {
+		comment = metadata.Comment
+	}
+
+	out := bufio.NewWriter(w)
+	out.WriteString("NAME: " + name + "\n")
+	out.WriteString("TYPE: " + problemType + "\n")
+	for _, commentLine := range strings.Split(comment, "\n") {
+		if commentLine != "" {
+			out.WriteString("COMMENT: " + commentLine + "\n")
+		}
+	}
+	out.WriteString("DIMENSION: " + strconv.Itoa(g.GetVertexCount()) + "\n")
+	out.WriteString("EDGE_WEIGHT_TYPE: " + EdgeWeightTypeExplicit + "\n")
+	out.WriteString("EDGE_WEIGHT_FORMAT: " + format + "\n")
+	out.WriteString("EDGE_WEIGHT_SECTION\n")
+
+	for i := 0; i < g.GetVertexCount(); i++ {
+		from, to := edgeWeightRowRange(format, g.GetVertexCount(), i)
+		if from >= to {
+			continue
+		}
+		for j := from; j < to; j++ {
+			out.WriteString(strconv.Itoa(g.GetEdge(i, j).Weight))
+			if j < to-1 {
+				out.WriteString(" ")
+			}
+		}
+		out.WriteString("\n")
+	}
+	out.WriteString("EOF\n")
+
+	return out.Flush()
+}
@@ -0,0 +1,173 @@
+package graph
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// symmetricTestMatrix to macierz symetryczna o różnych wagach, aby kolejność wartości w pliku miała znaczenie
+var symmetricTestMatrix = [][]int{
+	{0, 12, 13, 14},
+	{12, 0, 23, 24},
+	{13, 23, 0, 34},
+	{14, 24, 34, 0},
+}
+
+// tsplibEdgeWeights zapisuje macierz w kolejności wartości formatu według definicji TSPLIB
+func tsplibEdgeWeights(format string, matrix [][]int) []int {
+	n := len(matrix)
+	var values []int
+	rowWise := func(include func(i, j int) bool) {
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if include(i, j) {
+					values = append(values, matrix[i][j])
+				}
+			}
+		}
+	}
+	columnWise := func(include func(i, j int) bool) {
+		for j := 0; j < n; j++ {
+			for i := 0; i < n; i++ {
+				if include(i, j) {
+					values = append(values, matrix[i][j])
+				}
+			}
+		}
+	}
+	switch format {
+	case EdgeWeightFormatFullMatrix:
+		rowWise(func(i, j int) bool { return true })
+	case EdgeWeightFormatUpperRow:
+		rowWise(func(i, j int) bool { return j > i })
+	case EdgeWeightFormatLowerRow:
+		rowWise(func(i, j int) bool { return j < i })
+	case EdgeWeightFormatUpperDiagRow:
+		rowWise(func(i, j int) bool { return j >= i })
+	case EdgeWeightFormatLowerDiagRow:
+		rowWise(func(i, j int) bool { return j <= i })
+	case EdgeWeightFormatUpperCol:
+		columnWise(func(i, j int) bool { return i < j })
+	case EdgeWeightFormatLowerCol:
+		columnWise(func(i, j int) bool { return i > j })
+	case EdgeWeightFormatUpperDiagCol:
+		columnWise(func(i, j int) bool { return i <= j })
+	case EdgeWeightFormatLowerDiagCol:
+		columnWise(func(i, j int) bool { return i >= j })
+	}
+	return values
+}
+
+func explicitTSPLIB(format string, matrix [][]int) string {
+	var out strings.Builder
+	out.WriteString("NAME: test\nTYPE: TSP\nDIMENSION: " + strconv.Itoa(len(matrix)) + "\n")
+	out.WriteString("EDGE_WEIGHT_TYPE: EXPLICIT\nEDGE_WEIGHT_FORMAT: " + format + "\nEDGE_WEIGHT_SECTION\n")
+	for _, value := range tsplibEdgeWeights(format, matrix) {
+		out.WriteString(strconv.Itoa(value) + " ")
+	}
+	out.WriteString("\nEOF\n")
+	return out.String()
+}
+
+func TestExpandEdgeWeightsAllFormats(t *testing.T) {
+	const noEdgeValue = -1
+	tests := []struct {
+		format       string
+		wantDiagonal int
+	}{
+		{EdgeWeightFormatFullMatrix, 0}, // Przekątna z pliku
+		{EdgeWeightFormatUpperRow, noEdgeValue},
+		{EdgeWeightFormatLowerRow, noEdgeValue},
+		{EdgeWeightFormatUpperDiagRow, noEdgeValue},
+		{EdgeWeightFormatLowerDiagRow, noEdgeValue},
+		{EdgeWeightFormatUpperCol, noEdgeValue},
+		{EdgeWeightFormatLowerCol, noEdgeValue},
+		{EdgeWeightFormatUpperDiagCol, noEdgeValue},
+		{EdgeWeightFormatLowerDiagCol, noEdgeValue},
+	}
+	for _, test := range tests {
+		instance, err := parseTSPLIB(newLineScanner(strings.NewReader(explicitTSPLIB(test.format, symmetricTestMatrix))))
+		if err != nil {
+			t.Fatalf("%s: %v", test.format, err)
+		}
+		matrix, err := instance.buildMatrix(noEdgeValue)
+		if err != nil {
+			t.Fatalf("%s: %v", test.format, err)
+		}
+
+		want := make([][]int, len(symmetricTestMatrix))
+		for i, row := range symmetricTestMatrix {
+			want[i] = append([]int(nil), row...)
+			want[i][i] = test.wantDiagonal
+		}
+		if !reflect.DeepEqual(matrix, want) {
+			t.Errorf("%s: macierz %v, oczekiwano %v", test.format, matrix, want)
+		}
+	}
+}
+
+func TestLoadDiagonalFormatHasNoSelfLoops(t *testing.T) {
+	for _, format := range []string{EdgeWeightFormatUpperDiagRow, EdgeWeightFormatLowerDiagRow, EdgeWeightFormatUpperDiagCol, EdgeWeightFormatLowerDiagCol} {
+		filePath := filepath.Join(t.TempDir(), "test.tsp")
+		if err := os.WriteFile(filePath, []byte(explicitTSPLIB(format, symmetricTestMatrix)), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		g := NewAdjMatrixGraph(0, -1)
+		if _, err := LoadGraphFromFile(filePath, g); err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		for i := 0; i < g.GetVertexCount(); i++ {
+			if g.IsAdjacent(i, i) {
+				t.Errorf("%s: pętla %d -> %d o wadze %d", format, i, i, g.GetEdge(i, i).Weight)
+			}
+		}
+		if got, want := g.GetEdgeCount(), 4*3; got != want {
+			t.Errorf("%s: liczba krawędzi %d, oczekiwano %d", format, got, want)
+		}
+	}
+}
+
+func TestSaveGraphToTSPLIBFileFloatWeights(t *testing.T) {
+	tests := []struct {
+		name    string
+		weight  float64
+		wantErr bool
+	}{
+		{"wagi całkowite", 7, false},
+		{"waga niecałkowita", 7.25, true},
+	}
+	for _, test := range tests {
+		g := NewFloatMatrixGraph(0, -1)
+		fillGeneratedGraph(g, 3, -1, "test", 1, func(i, j int) int { return i + j })
+		g.setWeightFloat(0, 1, test.weight)
+		g.setWeightFloat(1, 0, test.weight)
+
+		filePath := filepath.Join(t.TempDir(), "test.tsp")
+		err := SaveGraphToTSPLIBFile(g, filePath, TSPLIBSaveOptions{})
+		if (err != nil) != test.wantErr {
+			t.Fatalf("%s: błąd %v, oczekiwano błędu: %v", test.name, err, test.wantErr)
+		}
+		if test.wantErr {
+			if _, statErr := os.Stat(filePath); !os.IsNotExist(statErr) {
+				t.Errorf("%s: plik został utworzony mimo błędu", test.name)
+			}
+			continue
+		}
+
+		loaded := NewFloatMatrixGraph(0, -1)
+		if _, err := LoadGraphFromFile(filePath, loaded); err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				if got, want := EdgeWeightFloat(loaded, i, j), EdgeWeightFloat(g, i, j); got != want {
+					t.Errorf("%s: waga %d -> %d po wczytaniu %v, oczekiwano %v", test.name, i, j, got, want)
+				}
+			}
+		}
+	}
+}
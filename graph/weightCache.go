@@ -0,0 +1,54 @@
+package graph
+
+import "container/list"
+
+// weightCache to ograniczona pamięć podręczna wag krawędzi z usuwaniem najdawniej używanych wpisów (LRU).
+// Nie jest bezpieczna przy równoczesnym użyciu z wielu goroutine.
+type weightCache struct {
+	capacity int
+	entries  map[int]*list.Element
+	order    *list.List // Od najnowszego (początek) do najstarszego (koniec)
+}
+
+type weightCacheEntry struct {
+	key    int
+	weight int
+}
+
+func newWeightCache(capacity int) *weightCache {
+	return &weightCache{
+		capacity: capacity,
+		entries:  make(map[int]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+// get zwraca zapamiętaną wagę i oznacza wpis jako ostatnio używany
+func (c *weightCache) get(key int) (int, bool) {
+	element, ok := c.entries[key]
+	if !ok {
+		return 0, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*weightCacheEntry).weight, true
+}
+
+// put zapamiętuje wagę, usuwając najdawniej używany wpis po przekroczeniu pojemności
+func (c *weightCache) put(key, weight int) {
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*weightCacheEntry).weight = weight
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&weightCacheEntry{key: key, weight: weight})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*weightCacheEntry).key)
+	}
+}
+
+// len zwraca liczbę zapamiętanych wag
+func (c *weightCache) len() int {
+	return c.order.Len()
+}
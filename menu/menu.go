@@ -10,8 +10,10 @@ import (
 	"time"
 
 	"projekt2/graph"
+	"projekt2/solver"
 	"projekt2/solver/bf"
 	"projekt2/solver/bnb"
+	"projekt2/solver/cache"
 	"projekt2/solver/dp"
 	"projekt2/solver/gr"
 	"projekt2/solver/sa"
@@ -20,39 +22,53 @@ import (
 
 // Menu struktura obsługująca dostępne funkcjonalności
 type Menu struct {
-	bfATSPSolver  bf.BFATSPSolver
-	bnbATSPSolver bnb.BNBATSPSolver
-	dpATSPSolver  dp.DPATSPSolver
-	grATSPSolver  gr.GRATSPSolver
-	saATSPSolver  sa.SaATSPSolver
-	tsATSPSolver  ts.TsATSPSolver
-	graph         graph.Graph
-	startVertex   int
+	bfATSPSolver    bf.BFATSPSolver
+	bnbATSPSolver   bnb.BNBATSPSolver
+	dpATSPSolver    dp.DPATSPSolver
+	grATSPSolver    gr.GRATSPSolver
+	saATSPSolver    sa.SaATSPSolver
+	tsATSPSolver    ts.TsATSPSolver
+	graph           graph.Graph
+	startVertex     int
+	lastPath        []int  // Ścieżka z ostatniego uruchomienia solvera
+	lastSolverName  string // Nazwa solvera, który znalazł lastPath
+	previousPath    []int  // Ścieżka z przedostatniego uruchomienia solvera (do porównania na rysunku SVG)
+	previousSolver  string // Nazwa solvera, który znalazł previousPath
+	representation  string // Reprezentacja pamięciowa nowo wczytywanych i generowanych grafów
+	weightCacheSize int    // Rozmiar pamięci podręcznej wag dla grafu wyliczanego ze współrzędnych
+	saParameters    string // Opis konfiguracji SA w kluczu pamięci podręcznej rozwiązań
+	tsParameters    string // Opis konfiguracji TS w kluczu pamięci podręcznej rozwiązań
+
+	solutionCachePath string               // Plik pamięci podręcznej rozwiązań
+	solutionCache     *cache.SolutionCache // Pamięć podręczna rozwiązań (nil - wyłączona, domyślnie)
 }
 
 // NewMenu tworzy nową instancję menu bez grafu
 func NewMenu() *Menu {
 	return &Menu{
-		startVertex:   0,
-		bfATSPSolver:  bf.BFATSPSolver{},
-		bnbATSPSolver: bnb.BNBATSPSolver{},
-		dpATSPSolver:  dp.DPATSPSolver{},
-		grATSPSolver:  gr.GRATSPSolver{},
-		saATSPSolver:  sa.SaATSPSolver{},
-		tsATSPSolver:  ts.TsATSPSolver{},
+		startVertex:    0,
+		representation: graph.RepresentationAdjMatrix,
+		bfATSPSolver:   bf.BFATSPSolver{},
+		bnbATSPSolver:  bnb.BNBATSPSolver{},
+		dpATSPSolver:   dp.DPATSPSolver{},
+		grATSPSolver:   gr.GRATSPSolver{},
+		saATSPSolver:   sa.SaATSPSolver{},
+		tsATSPSolver:   ts.TsATSPSolver{},
 	}
 }
 
 // NewDefaultMenu tworzy nową instancję menu z podanym grafem
 func NewDefaultMenu(g graph.Graph) *Menu {
 	return &Menu{
-		graph: g,
+		graph:          g,
+		representation: graph.RepresentationAdjMatrix,
 	}
 }
 
-// SetGraph ustawia graf
+// SetGraph ustawia graf; zapamiętane rozwiązania poprzedniego grafu są zapominane
 func (m *Menu) SetGraph(g graph.Graph) {
 	m.graph = g
+	m.clearSolutions()
 	m.bnbATSPSolver.SetGraph(g)
 	m.bfATSPSolver.SetGraph(g)
 	m.dpATSPSolver.SetGraph(g)
@@ -77,22 +93,59 @@ func (m *Menu) SetStartVertex(startVertex int) {
 	m.tsATSPSolver.SetStartVertex(startVertex)
 }
 
-// LoadGraphFromFile wczytuje graf z pliku
-func (m *Menu) LoadGraphFromFile(filePath string, isATSP bool) error {
-	adjGraph := &graph.AdjMatrixGraph{}
-	err := graph.LoadGraphFromFile(filePath, adjGraph, isATSP)
+// SetRepresentation ustawia reprezentację pamięciową (graph.Representation*) dla kolejnych grafów
+func (m *Menu) SetRepresentation(representation string) error {
+	if representation == graph.RepresentationImplicit {
+		m.representation = representation
+		return nil
+	}
+	if _, err := graph.NewMatrixGraph(representation, 0); err != nil {
+		return err
+	}
+	m.representation = representation
+	return nil
+}
+
+// newGraph tworzy pusty graf w wybranej reprezentacji; dla reprezentacji IMPLICIT,
+// która nie przechowuje macierzy, zwracany jest AdjMatrixGraph
+func (m *Menu) newGraph() graph.MatrixGraph {
+	g, err := graph.NewMatrixGraph(m.representation, 0)
+	if err != nil {
+		g = graph.NewAdjMatrixGraph(0, 0)
+	}
+	return g
+}
+
+// LoadGraphFromFile wczytuje graf z pliku, rozpoznając jego format automatycznie
+func (m *Menu) LoadGraphFromFile(filePath string) error {
+	if m.representation == graph.RepresentationImplicit {
+		g, err := graph.LoadImplicitGraphFromTSPLIBFile(filePath, 0, m.weightCacheSize)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Wczytano współrzędne, wagi będą wyliczane na żądanie.")
+		m.SetGraph(g)
+		return nil
+	}
+	g := m.newGraph()
+	format, err := graph.LoadGraphFromFile(filePath, g)
 	if err != nil {
 		return err
 	}
-	m.SetGraph(adjGraph)
+	fmt.Println("Wykryty format pliku:", format)
+	m.SetGraph(g)
 	return nil
 }
 
-// GenerateRandomGraph generuje losowy graf
-func (m *Menu) GenerateRandomGraph(vertexCount, noEdgeValue, maxWeight int) {
-	adjGraph := &graph.AdjMatrixGraph{}
-	graph.GenerateRandomGraph(adjGraph, vertexCount, noEdgeValue, maxWeight)
-	m.SetGraph(adjGraph)
+// GenerateGraph generuje graf wybranej klasy z podanego ziarna; ziarno jest zapisywane w metadanych grafu.
+// Przy błędzie generatora bieżący graf pozostaje bez zmian.
+func (m *Menu) GenerateGraph(generate instanceGenerator, vertexCount, noEdgeValue int, seed int64) error {
+	g := m.newGraph()
+	if err := generate(g, vertexCount, noEdgeValue, seed); err != nil {
+		return err
+	}
+	m.SetGraph(g)
+	return nil
 }
 
 // SetNoEdgeValue ustawia wartość braku krawędzi
@@ -113,6 +166,15 @@ func (m *Menu) DisplayGraph() {
 	}
 }
 
+// AnalyzeGraph wyświetla raport z analizy aktualnego grafu (graph.AnalyzeGraph)
+func (m *Menu) AnalyzeGraph() {
+	if m.graph == nil {
+		fmt.Println("Graf nie został zainicjalizowany.")
+		return
+	}
+	fmt.Print(graph.AnalyzeGraph(m.graph).ToString())
+}
+
 // Konfiguracja solverów bez dodatkowych parametrów - tylko start vertex
 func (m *Menu) ConfigureBfSolver() {
 	m.bfATSPSolver = bf.NewBruteForceATSPSolver(m.startVertex)
@@ -144,6 +206,8 @@ func (m *Menu) ConfigureSaSolver(initialTemperature float64, minimalTemperature
 	solver.SetGraph(m.graph)
 	solver.SetStartVertex(m.startVertex)
 	m.saATSPSolver = solver
+	m.saParameters = fmt.Sprintf("initialTemperature=%g,minimalTemperature=%g,alpha=%g,iterations=%d,timeout=%d",
+		initialTemperature, minimalTemperature, alpha, iterations, timeout)
 	fmt.Println("SA skonfigurowane.")
 }
 
@@ -159,19 +223,127 @@ func (m *Menu) ConfigureTsSolver(iterations int, timeout int64, tabuTenure int,
 		fmt.Printf("Błąd ustawiania metody sąsiedztwa: %v. Użyto domyślnej metody 'swap'.\n", err)
 	}
 	m.tsATSPSolver = solver
+	m.tsParameters = fmt.Sprintf("iterations=%d,timeout=%d,tabuTenure=%d,neighborhood=%s",
+		iterations, timeout, tabuTenure, solver.GetNeighborhoodMethod())
 	fmt.Println("TS skonfigurowane.")
 }
 
-// printSolution wypisuje ścieżkę i koszt
-func (m *Menu) printSolution(path []int, cost int) {
+// solve uruchamia solver; dla grafów z wagami zmiennoprzecinkowymi używa SolveFloat, aby nie zaokrąglać kosztów.
+// Przy włączonej pamięci podręcznej rozwiązań wyniki są w niej zapisywane, a solver dokładny (exact) zwraca
+// zapamiętane optimum bez obliczeń - wtedy trzecia zwracana wartość to true.
+func (m *Menu) solve(solverName, parameters string, exact bool, s solver.FloatATSPSolver) ([]int, string, bool) {
+	var cached *cache.CachedSolver
+	if m.solutionCache != nil {
+		cached = cache.NewCachedSolver(s, m.solutionCache, solverName, parameters, 0, exact)
+		cached.SetStartVertex(m.startVertex)
+		s = cached
+	}
+	var path []int
+	var cost string
+	if graph.IsFloatGraph(s.GetGraph()) {
+		floatPath, floatCost := s.SolveFloat()
+		path, cost = floatPath, graph.FormatWeight(floatCost)
+	} else {
+		intPath, intCost := s.Solve()
+		path, cost = intPath, graph.FormatWeight(intCost)
+	}
+	return path, cost, cached != nil && cached.LastHit()
+}
+
+// printElapsed wypisuje czas wykonania solvera albo informację, że wynik pochodzi z pamięci podręcznej
+func (m *Menu) printElapsed(solverLabel string, elapsed time.Duration, fromCache bool) {
+	if fromCache {
+		fmt.Println("Wynik z pamięci podręcznej rozwiązań (" + m.solutionCachePath + ") - " + solverLabel + " nie był uruchamiany.")
+		return
+	}
+	fmt.Println("Czas wykonania "+solverLabel+":", elapsed)
+}
+
+// solutionCacheStatus zwraca plik pamięci podręcznej rozwiązań lub informację, że jest wyłączona
+func (m *Menu) solutionCacheStatus() string {
+	if m.solutionCache == nil {
+		return "wyłączona"
+	}
+	return m.solutionCachePath
+}
+
+// SetSolutionCache włącza pamięć podręczną rozwiązań zapisywaną w podanym pliku; pusta ścieżka ją wyłącza
+func (m *Menu) SetSolutionCache(filePath string) error {
+	if filePath == "" {
+		m.solutionCache, m.solutionCachePath = nil, ""
+		return nil
+	}
+	c, err := cache.LoadSolutionCache(filePath)
+	if err != nil {
+		return err
+	}
+	m.solutionCache, m.solutionCachePath = c, filePath
+	return nil
+}
+
+// printSolution wypisuje ścieżkę i koszt oraz zapamiętuje ją jako ostatnie rozwiązanie
+func (m *Menu) printSolution(solverName string, path []int, cost string) {
 	if path == nil {
 		fmt.Println("Nie znaleziono rozwiązania.")
 		return
 	}
+	m.previousPath, m.previousSolver = m.lastPath, m.lastSolverName
+	m.lastPath, m.lastSolverName = path, solverName
 	fmt.Println("Koszt:", cost)
 	if m.graph != nil {
 		fmt.Println("Ścieżka ze szczegółami wag:", m.graph.PathWithWeightsToString(path))
 	}
+	if subgraph, ok := m.graph.(graph.Subgraph); ok {
+		fmt.Println("Ścieżka w numeracji oryginalnego grafu:", subgraph.ToOriginalPath(path))
+	}
+}
+
+// RestrictToVertices zastępuje aktualny graf widokiem ograniczonym do podanych wierzchołków (np. "0-19" lub "1,4,7").
+// Numery odnoszą się zawsze do pełnego grafu; pusta lista przywraca pełny graf.
+func (m *Menu) RestrictToVertices(vertexList string) error {
+	if m.graph == nil {
+		return fmt.Errorf("brak grafu do ograniczenia")
+	}
+	fullGraph := m.graph
+	if subgraph, ok := m.graph.(graph.Subgraph); ok {
+		fullGraph = subgraph.Parent()
+	}
+	if strings.TrimSpace(vertexList) == "" {
+		m.SetGraph(fullGraph)
+		fmt.Println("Przywrócono pełny graf.")
+		return nil
+	}
+
+	vertices, err := graph.ParseVertexList(vertexList, fullGraph.GetVertexCount())
+	if err != nil {
+		return err
+	}
+	subgraph, err := graph.NewSubgraph(fullGraph, vertices)
+	if err != nil {
+		return err
+	}
+	m.SetGraph(subgraph)
+	if m.startVertex >= subgraph.GetVertexCount() {
+		m.SetStartVertex(0)
+	}
+	fmt.Println("Graf ograniczono do", subgraph.GetVertexCount(), "wierzchołków.")
+	return nil
+}
+
+// clearSolutions zapomina zapamiętane rozwiązania, gdy przestają odpowiadać aktualnemu grafowi
+func (m *Menu) clearSolutions() {
+	m.lastPath, m.lastSolverName = nil, ""
+	m.previousPath, m.previousSolver = nil, ""
+}
+
+// checkPath sprawdza, czy wszystkie wierzchołki zapamiętanej ścieżki należą do aktualnego grafu
+func (m *Menu) checkPath(path []int) error {
+	for _, v := range path {
+		if v < 0 || v >= m.graph.GetVertexCount() {
+			return fmt.Errorf("zapamiętana trasa zawiera wierzchołek %d spoza aktualnego grafu, uruchom solver ponownie", v)
+		}
+	}
+	return nil
 }
 
 // RunBf uruchamia brute force
@@ -181,10 +353,10 @@ func (m *Menu) RunBf() {
 		return
 	}
 	start := time.Now()
-	path, cost := m.bfATSPSolver.Solve()
+	path, cost, fromCache := m.solve("BF", "", true, &m.bfATSPSolver)
 	elapsed := time.Since(start)
-	m.printSolution(path, cost)
-	fmt.Println("Czas wykonania Brute Force:", elapsed)
+	m.printSolution("BF", path, cost)
+	m.printElapsed("Brute Force", elapsed, fromCache)
 }
 
 // RunBnb uruchamia branch and bound
@@ -194,10 +366,10 @@ func (m *Menu) RunBnb() {
 		return
 	}
 	start := time.Now()
-	path, cost := m.bnbATSPSolver.Solve()
+	path, cost, fromCache := m.solve("BnB", fmt.Sprintf("matrixReduction=%t", m.bnbATSPSolver.GetMatrixReduction()), true, &m.bnbATSPSolver)
 	elapsed := time.Since(start)
-	m.printSolution(path, cost)
-	fmt.Println("Czas wykonania BnB:", elapsed)
+	m.printSolution("BnB", path, cost)
+	m.printElapsed("BnB", elapsed, fromCache)
 }
 
 // RunDp uruchamia dynamic programming
@@ -207,10 +379,10 @@ func (m *Menu) RunDp() {
 		return
 	}
 	start := time.Now()
-	path, cost := m.dpATSPSolver.Solve()
+	path, cost, fromCache := m.solve("DP", "", true, &m.dpATSPSolver)
 	elapsed := time.Since(start)
-	m.printSolution(path, cost)
-	fmt.Println("Czas wykonania DP:", elapsed)
+	m.printSolution("DP", path, cost)
+	m.printElapsed("DP", elapsed, fromCache)
 }
 
 // RunGr uruchamia greedy solver
@@ -220,10 +392,10 @@ func (m *Menu) RunGr() {
 		return
 	}
 	start := time.Now()
-	path, cost := m.grATSPSolver.Solve()
+	path, cost, fromCache := m.solve("GR", "", false, &m.grATSPSolver)
 	elapsed := time.Since(start)
-	m.printSolution(path, cost)
-	fmt.Println("Czas wykonania Greedy:", elapsed)
+	m.printSolution("GR", path, cost)
+	m.printElapsed("Greedy", elapsed, fromCache)
 }
 
 // RunSa uruchamia simulated annealing
@@ -233,10 +405,10 @@ func (m *Menu) RunSa() {
 		return
 	}
 	start := time.Now()
-	path, cost := m.saATSPSolver.Solve()
+	path, cost, fromCache := m.solve("SA", m.saParameters, false, &m.saATSPSolver)
 	elapsed := time.Since(start)
-	m.printSolution(path, cost)
-	fmt.Println("Czas wykonania SA:", elapsed)
+	m.printSolution("SA", path, cost)
+	m.printElapsed("SA", elapsed, fromCache)
 }
 
 // RunTs uruchamia tabu search
@@ -246,10 +418,10 @@ func (m *Menu) RunTs() {
 		return
 	}
 	start := time.Now()
-	path, cost := m.tsATSPSolver.Solve()
+	path, cost, fromCache := m.solve("TS", m.tsParameters, false, &m.tsATSPSolver)
 	elapsed := time.Since(start)
-	m.printSolution(path, cost)
-	fmt.Println("Czas wykonania Tabu Search:", elapsed)
+	m.printSolution("TS", path, cost)
+	m.printElapsed("Tabu Search", elapsed, fromCache)
 }
 
 // SaveGraphToFile zapis grafu do pliku
@@ -265,6 +437,146 @@ func (m *Menu) SaveGraphToFile(filePath string, useTabs bool) error {
 	return nil
 }
 
+// SaveGraphToTSPLIBFile zapis grafu do pliku w formacie TSPLIB z wybranym układem macierzy
+func (m *Menu) SaveGraphToTSPLIBFile(filePath string, edgeWeightFormat string) error {
+	if m.graph == nil {
+		return fmt.Errorf("brak grafu do zapisania")
+	}
+	err := graph.SaveGraphToTSPLIBFile(m.graph, filePath, graph.TSPLIBSaveOptions{EdgeWeightFormat: edgeWeightFormat})
+	if err != nil {
+		return err
+	}
+	fmt.Println("Graf zapisany do pliku TSPLIB:", filePath)
+	return nil
+}
+
+// SaveGraphToJSONFile zapis grafu do pliku JSON (pełna macierz lub lista krawędzi)
+func (m *Menu) SaveGraphToJSONFile(filePath string, sparse bool) error {
+	if m.graph == nil {
+		return fmt.Errorf("brak grafu do zapisania")
+	}
+	err := graph.SaveGraphToJSONFile(m.graph, filePath, sparse)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Graf zapisany do pliku JSON:", filePath)
+	return nil
+}
+
+// SaveGraphToCSVEdgeList zapis krawędzi grafu do pliku CSV (from,to,weight)
+func (m *Menu) SaveGraphToCSVEdgeList(filePath string) error {
+	if m.graph == nil {
+		return fmt.Errorf("brak grafu do zapisania")
+	}
+	err := graph.SaveGraphToCSVEdgeList(m.graph, filePath)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Krawędzie grafu zapisane do pliku CSV:", filePath)
+	return nil
+}
+
+// SaveGraphToBinaryFile zapis grafu w zwartym formacie binarnym
+func (m *Menu) SaveGraphToBinaryFile(filePath string) error {
+	if m.graph == nil {
+		return fmt.Errorf("brak grafu do zapisania")
+	}
+	err := graph.SaveGraphToBinaryFile(m.graph, filePath)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Graf zapisany do pliku binarnego:", filePath)
+	return nil
+}
+
+// SaveLastTourToFile zapisuje ostatnio znalezioną ścieżkę do pliku .tour
+func (m *Menu) SaveLastTourToFile(filePath string) error {
+	if m.graph == nil || m.lastPath == nil {
+		return fmt.Errorf("brak rozwiązania do zapisania, najpierw uruchom solver")
+	}
+	if err := m.checkPath(m.lastPath); err != nil {
+		return err
+	}
+	name := graph.GetGraphMetadata(m.graph).Name
+	comment := "Koszt: " + graph.FormatPathWeight(m.graph, m.lastPath)
+	err := graph.SaveTourToFile(filePath, m.lastPath, name, comment)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Trasa zapisana do pliku:", filePath)
+	return nil
+}
+
+// SaveGraphToDOTFile zapisuje graf w formacie DOT (Graphviz), opcjonalnie z wyróżnioną ostatnio znalezioną trasą
+func (m *Menu) SaveGraphToDOTFile(filePath string, withTour, hideOtherEdges bool) error {
+	if m.graph == nil {
+		return fmt.Errorf("brak grafu do zapisania")
+	}
+	options := graph.DOTOptions{HideOtherEdges: hideOtherEdges}
+	if withTour {
+		if m.lastPath == nil {
+			return fmt.Errorf("brak rozwiązania do wyróżnienia, najpierw uruchom solver")
+		}
+		if err := m.checkPath(m.lastPath); err != nil {
+			return err
+		}
+		options.Tour = m.lastPath
+	}
+	err := graph.SaveGraphToDOTFile(m.graph, filePath, options)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Graf zapisany do pliku DOT:", filePath)
+	return nil
+}
+
+// SaveToursToSVGFile rysuje ostatnio znalezioną trasę do pliku SVG; withPrevious dodaje obok trasę z poprzedniego
+// uruchomienia solvera, aby porównać wyniki dwóch solverów
+func (m *Menu) SaveToursToSVGFile(filePath string, withPrevious bool) error {
+	if m.graph == nil {
+		return fmt.Errorf("brak grafu do narysowania")
+	}
+	if m.lastPath == nil {
+		return fmt.Errorf("brak rozwiązania do narysowania, najpierw uruchom solver")
+	}
+	tours := []graph.SVGTour{{Title: m.lastSolverName, Path: m.lastPath}}
+	if withPrevious {
+		if m.previousPath == nil {
+			return fmt.Errorf("brak poprzedniego rozwiązania do porównania, uruchom dwa solvery")
+		}
+		tours = []graph.SVGTour{{Title: m.previousSolver, Path: m.previousPath}, tours[0]}
+	}
+	for _, tour := range tours {
+		if err := m.checkPath(tour.Path); err != nil {
+			return err
+		}
+	}
+	err := graph.SaveToursToSVGFile(m.graph, filePath, tours, graph.SVGOptions{ShowLabels: m.graph.GetVertexCount() <= 100})
+	if err != nil {
+		return err
+	}
+	fmt.Println("Rysunek zapisany do pliku SVG:", filePath)
+	return nil
+}
+
+// EvaluateTourFromFile wczytuje trasę z pliku .tour i oblicza jej koszt w aktualnym grafie
+func (m *Menu) EvaluateTourFromFile(filePath string) error {
+	if m.graph == nil {
+		return fmt.Errorf("brak grafu, względem którego można ocenić trasę")
+	}
+	tour, err := graph.LoadTourFromFile(filePath)
+	if err != nil {
+		return err
+	}
+	if _, err := graph.EvaluateTour(m.graph, tour.Path); err != nil {
+		return err
+	}
+	fmt.Println("Trasa:", tour.Name)
+	fmt.Println("Koszt:", graph.FormatPathWeight(m.graph, tour.Path))
+	fmt.Println("Ścieżka ze szczegółami wag:", m.graph.PathWithWeightsToString(tour.Path))
+	return nil
+}
+
 // Submenu konfiguracji solverów
 func (m *Menu) solverConfigurationSubmenu() {
 	reader := bufio.NewReader(os.Stdin)
@@ -311,6 +623,10 @@ func (m *Menu) solverConfigurationSubmenu() {
 				}
 			}
 			m.ConfigureBnbSolver()
+			fmt.Print("Czy redukować macierz wag przed przeszukiwaniem? (t/n): ")
+			reduce, _ := reader.ReadString('\n')
+			reduce = strings.TrimSpace(reduce)
+			m.bnbATSPSolver.SetMatrixReduction(reduce == "t" || reduce == "T")
 		case "3":
 			// DP - tylko ustawienie start vertex
 			fmt.Print("Podaj wierzchołek startowy (lub enter aby nie zmieniać): ")
@@ -376,6 +692,12 @@ func (m *Menu) solverConfigurationSubmenu() {
 			}
 
 			m.ConfigureSaSolver(initialTemp, minTemp, alpha, iterations, timeout)
+			candidateMethod, candidateCount := readCandidateLists(reader)
+			if err := m.saATSPSolver.SetCandidateLists(candidateMethod, candidateCount); err != nil {
+				fmt.Println("Błąd ustawiania list kandydatów:", err)
+			} else if candidateMethod != graph.CandidatesNone {
+				m.saParameters += fmt.Sprintf(",candidates=%s/%d", candidateMethod, candidateCount)
+			}
 		case "6":
 			// TS - konfiguracja parametrów
 			fmt.Println("Konfiguracja Tabu Search:")
@@ -421,6 +743,12 @@ func (m *Menu) solverConfigurationSubmenu() {
 			}
 
 			m.ConfigureTsSolver(iterations, timeout, tabuTenure, neighborhoodMethod)
+			candidateMethod, candidateCount := readCandidateLists(reader)
+			if err := m.tsATSPSolver.SetCandidateLists(candidateMethod, candidateCount); err != nil {
+				fmt.Println("Błąd ustawiania list kandydatów:", err)
+			} else if candidateMethod != graph.CandidatesNone {
+				m.tsParameters += fmt.Sprintf(",candidates=%s/%d", candidateMethod, candidateCount)
+			}
 		case "b", "B":
 			// Powrót do głównego menu
 			return
@@ -443,6 +771,15 @@ func (m *Menu) RunInteractiveMenu() {
 		fmt.Println("5. Konfiguruj solvery")
 		fmt.Println("6. Uruchom solvery")
 		fmt.Println("7. Ustaw noEdgeValue w grafie")
+		fmt.Println("8. Zapisz graf do pliku")
+		fmt.Println("9. Wczytaj trasę z pliku .tour i oblicz jej koszt")
+		fmt.Println("10. Zapisz ostatnie rozwiązanie do pliku .tour")
+		fmt.Println("11. Wybierz reprezentację grafu (aktualnie: " + m.representation + ")")
+		fmt.Println("12. Analiza wczytanego grafu")
+		fmt.Println("13. Ogranicz graf do wybranych wierzchołków...")
+		fmt.Println("14. Eksportuj graf do formatu DOT (Graphviz)")
+		fmt.Println("15. Narysuj ostatnie rozwiązanie do pliku SVG")
+		fmt.Println("16. Pamięć podręczna rozwiązań (aktualnie: " + m.solutionCacheStatus() + ")")
 		fmt.Println("q. Wyjście")
 		fmt.Print("Wybierz opcję: ")
 
@@ -456,12 +793,7 @@ func (m *Menu) RunInteractiveMenu() {
 			filePath, _ := reader.ReadString('\n')
 			filePath = strings.TrimSpace(filePath)
 
-			fmt.Print("Czy to format ATSP? (y/n): ")
-			atspLine, _ := reader.ReadString('\n')
-			atspLine = strings.TrimSpace(atspLine)
-			isATSP := (atspLine == "y" || atspLine == "Y")
-
-			err := m.LoadGraphFromFile(filePath, isATSP)
+			err := m.LoadGraphFromFile(filePath)
 			if err != nil {
 				fmt.Println("Błąd wczytywania grafu:", err)
 			} else {
@@ -487,17 +819,29 @@ func (m *Menu) RunInteractiveMenu() {
 				break
 			}
 
-			fmt.Print("Podaj maksymalną wagę krawędzi: ")
-			mwStr, _ := reader.ReadString('\n')
-			mwStr = strings.TrimSpace(mwStr)
-			mw, err := strconv.Atoi(mwStr)
-			if err != nil || mw <= 0 {
-				fmt.Println("Nieprawidłowa maksymalna waga krawędzi.")
+			generate, err := readInstanceClass(reader)
+			if err != nil {
+				fmt.Println("Błąd wyboru klasy instancji:", err)
 				break
 			}
 
-			m.GenerateRandomGraph(vc, neVal, mw)
-			fmt.Println("Wygenerowano losowy graf.")
+			fmt.Print("Podaj ziarno generatora (enter - nowe losowe ziarno): ")
+			seedStr, _ := reader.ReadString('\n')
+			seedStr = strings.TrimSpace(seedStr)
+			seed := graph.NewSeed()
+			if seedStr != "" {
+				seed, err = strconv.ParseInt(seedStr, 10, 64)
+				if err != nil {
+					fmt.Println("Nieprawidłowe ziarno.")
+					break
+				}
+			}
+
+			if err := m.GenerateGraph(generate, vc, neVal, seed); err != nil {
+				fmt.Println("Błąd generowania grafu:", err)
+				break
+			}
+			fmt.Println("Wygenerowano graf", graph.GetGraphMetadata(m.graph).Name, "(ziarno:", strconv.FormatInt(seed, 10)+").")
 		case "3":
 			// Wyświetl aktualny graf
 			m.DisplayGraph()
@@ -571,6 +915,157 @@ func (m *Menu) RunInteractiveMenu() {
 			}
 			m.SetNoEdgeValue(nev)
 			fmt.Println("Ustawiono noEdgeValue na:", nev)
+		case "8":
+			// Zapisz graf do pliku
+			if m.graph == nil {
+				fmt.Println("Najpierw wczytaj lub wygeneruj graf.")
+				break
+			}
+			fmt.Print("Podaj ścieżkę do pliku: ")
+			filePath, _ := reader.ReadString('\n')
+			filePath = strings.TrimSpace(filePath)
+
+			fmt.Println("Wybierz format zapisu:")
+			fmt.Println("1. Liczba wierzchołków + macierz")
+			fmt.Println("2. TSPLIB - pełna macierz (FULL_MATRIX)")
+			fmt.Println("3. TSPLIB - górny trójkąt (UPPER_ROW)")
+			fmt.Println("4. TSPLIB - dolny trójkąt z przekątną (LOWER_DIAG_ROW)")
+			fmt.Println("5. JSON - pełna macierz")
+			fmt.Println("6. JSON - lista krawędzi")
+			fmt.Println("7. CSV - lista krawędzi (from,to,weight)")
+			fmt.Println("8. Binarny (szybki zapis i odczyt dużych macierzy)")
+			fmt.Print("Wybierz opcję: ")
+			formatOpt, _ := reader.ReadString('\n')
+			formatOpt = strings.TrimSpace(formatOpt)
+
+			var err error
+			switch formatOpt {
+			case "1":
+				err = m.SaveGraphToFile(filePath, false)
+			case "2":
+				err = m.SaveGraphToTSPLIBFile(filePath, graph.EdgeWeightFormatFullMatrix)
+			case "3":
+				err = m.SaveGraphToTSPLIBFile(filePath, graph.EdgeWeightFormatUpperRow)
+			case "4":
+				err = m.SaveGraphToTSPLIBFile(filePath, graph.EdgeWeightFormatLowerDiagRow)
+			case "5":
+				err = m.SaveGraphToJSONFile(filePath, false)
+			case "6":
+				err = m.SaveGraphToJSONFile(filePath, true)
+			case "7":
+				err = m.SaveGraphToCSVEdgeList(filePath)
+			case "8":
+				err = m.SaveGraphToBinaryFile(filePath)
+			default:
+				fmt.Println("Nieznana opcja.")
+			}
+			if err != nil {
+				fmt.Println("Błąd zapisu grafu:", err)
+			}
+		case "9":
+			// Wczytaj trasę z pliku .tour i oblicz jej koszt
+			fmt.Print("Podaj ścieżkę do pliku z trasą: ")
+			filePath, _ := reader.ReadString('\n')
+			filePath = strings.TrimSpace(filePath)
+			if err := m.EvaluateTourFromFile(filePath); err != nil {
+				fmt.Println("Błąd oceny trasy:", err)
+			}
+		case "10":
+			// Zapisz ostatnie rozwiązanie do pliku .tour
+			fmt.Print("Podaj ścieżkę do pliku: ")
+			filePath, _ := reader.ReadString('\n')
+			filePath = strings.TrimSpace(filePath)
+			if err := m.SaveLastTourToFile(filePath); err != nil {
+				fmt.Println("Błąd zapisu trasy:", err)
+			}
+		case "11":
+			// Wybierz reprezentację grafu
+			fmt.Println("Reprezentacja dotyczy grafów wczytanych lub wygenerowanych po zmianie:")
+			fmt.Println("1. Macierz [][]int (domyślna)")
+			fmt.Println("2. Płaska macierz int32 (mniejsze zużycie pamięci)")
+			fmt.Println("3. Płaska macierz int64")
+			fmt.Println("4. Listy sąsiedztwa (grafy rzadkie)")
+			fmt.Println("5. Wagi wyliczane ze współrzędnych (tylko wczytywanie TSPLIB ze współrzędnymi)")
+			fmt.Println("6. Macierz wag zmiennoprzecinkowych (float64)")
+			fmt.Print("Wybierz opcję: ")
+			choice, _ := reader.ReadString('\n')
+			choice = strings.TrimSpace(choice)
+			switch choice {
+			case "1":
+				m.representation = graph.RepresentationAdjMatrix
+			case "2":
+				m.representation = graph.RepresentationFlat32
+			case "3":
+				m.representation = graph.RepresentationFlat64
+			case "4":
+				m.representation = graph.RepresentationAdjList
+			case "5":
+				cacheSize, err := readInt("Podaj rozmiar pamięci podręcznej wag (0 = brak): ")
+				if err != nil || cacheSize < 0 {
+					fmt.Println("Nieprawidłowy rozmiar pamięci podręcznej.")
+					break
+				}
+				m.weightCacheSize = cacheSize
+				m.representation = graph.RepresentationImplicit
+			case "6":
+				m.representation = graph.RepresentationFloat
+			default:
+				fmt.Println("Nieznana opcja.")
+			}
+			fmt.Println("Reprezentacja grafu:", m.representation)
+		case "12":
+			// Analiza wczytanego grafu
+			m.AnalyzeGraph()
+		case "13":
+			// Ogranicz graf do wybranych wierzchołków
+			fmt.Print("Podaj wierzchołki pełnego grafu (np. 0-19 lub 1,4,7; pusty wiersz przywraca pełny graf): ")
+			vertexList, _ := reader.ReadString('\n')
+			if err := m.RestrictToVertices(vertexList); err != nil {
+				fmt.Println("Błąd ograniczania grafu:", err)
+			}
+		case "14":
+			// Eksportuj graf do formatu DOT
+			fmt.Print("Podaj ścieżkę do pliku .dot: ")
+			filePath, _ := reader.ReadString('\n')
+			filePath = strings.TrimSpace(filePath)
+			withTour, hideOtherEdges := false, false
+			if m.lastPath != nil {
+				fmt.Print("Czy wyróżnić ostatnio znalezioną trasę? (t/n): ")
+				answer, _ := reader.ReadString('\n')
+				withTour = strings.EqualFold(strings.TrimSpace(answer), "t")
+			}
+			if withTour {
+				fmt.Print("Czy ukryć krawędzie spoza trasy zamiast je wyszarzać? (t/n): ")
+				answer, _ := reader.ReadString('\n')
+				hideOtherEdges = strings.EqualFold(strings.TrimSpace(answer), "t")
+			}
+			if err := m.SaveGraphToDOTFile(filePath, withTour, hideOtherEdges); err != nil {
+				fmt.Println("Błąd eksportu grafu:", err)
+			}
+		case "15":
+			// Narysuj ostatnie rozwiązanie do pliku SVG
+			fmt.Print("Podaj ścieżkę do pliku .svg: ")
+			filePath, _ := reader.ReadString('\n')
+			filePath = strings.TrimSpace(filePath)
+			withPrevious := false
+			if m.previousPath != nil {
+				fmt.Print("Czy narysować obok rozwiązanie poprzedniego solvera (" + m.previousSolver + ")? (t/n): ")
+				answer, _ := reader.ReadString('\n')
+				withPrevious = strings.EqualFold(strings.TrimSpace(answer), "t")
+			}
+			if err := m.SaveToursToSVGFile(filePath, withPrevious); err != nil {
+				fmt.Println("Błąd rysowania trasy:", err)
+			}
+		case "16":
+			// Włącz lub wyłącz pamięć podręczną rozwiązań
+			fmt.Print("Podaj plik pamięci podręcznej (np. " + cache.DefaultCacheFile + "; pusty wiersz wyłącza): ")
+			filePath, _ := reader.ReadString('\n')
+			filePath = strings.TrimSpace(filePath)
+			if err := m.SetSolutionCache(filePath); err != nil {
+				fmt.Println("Błąd wczytywania pamięci podręcznej rozwiązań:", err)
+				break
+			}
+			fmt.Println("Pamięć podręczna rozwiązań:", m.solutionCacheStatus())
 		case "q", "Q":
 			// Wyjście z menu
 			fmt.Println("Zakończono działanie programu.")
@@ -581,6 +1076,152 @@ func (m *Menu) RunInteractiveMenu() {
 	}
 }
 
+// instanceGenerator generuje instancję o podanej liczbie wierzchołków z podanego ziarna
+type instanceGenerator func(g graph.MatrixGraph, vertexCount, noEdgeValue int, seed int64) error
+
+// readInstanceClass pyta o klasę generowanej instancji i jej parametry.
+// Klasy DIMACS używają stałych parametrów zbliżonych do instancji z DIMACS Implementation Challenge.
+func readInstanceClass(reader *bufio.Reader) (instanceGenerator, error) {
+	fmt.Println("Wybierz klasę instancji:")
+	fmt.Println("1. Losowa macierz wag 1..maxWeight")
+	fmt.Println("2. Punkty jednostajne w kwadracie (euklidesowa)")
+	fmt.Println("3. Skupiska gaussowskie (euklidesowa)")
+	fmt.Println("4. Siatka z zaburzeniem (euklidesowa)")
+	fmt.Println("5. Instancja drogowa (asymetryczne zaburzenie odległości)")
+	fmt.Println("6. DIMACS amat")
+	fmt.Println("7. DIMACS tmat")
+	fmt.Println("8. DIMACS rtilt")
+	fmt.Println("9. DIMACS stilt")
+	fmt.Println("10. DIMACS crane")
+	fmt.Println("11. DIMACS disk")
+	fmt.Println("12. DIMACS coin")
+	fmt.Println("13. DIMACS shop")
+	fmt.Println("14. DIMACS super")
+	fmt.Println("15. DIMACS rect")
+	fmt.Print("Wybierz opcję: ")
+	choice, _ := reader.ReadString('\n')
+	choice = strings.TrimSpace(choice)
+
+	switch choice {
+	case "1":
+		maxWeight, err := readInt("Podaj maksymalną wagę krawędzi: ")
+		if err != nil || maxWeight <= 0 {
+			return nil, fmt.Errorf("nieprawidłowa maksymalna waga krawędzi")
+		}
+		return func(g graph.MatrixGraph, vertexCount, noEdgeValue int, seed int64) error {
+			graph.GenerateRandomGraphWithSeed(g, vertexCount, noEdgeValue, maxWeight, seed)
+			return nil
+		}, nil
+	case "2", "3", "4", "5":
+		squareSize, err := readInt("Podaj bok kwadratu: ")
+		if err != nil || squareSize <= 0 {
+			return nil, fmt.Errorf("nieprawidłowy bok kwadratu")
+		}
+		switch choice {
+		case "2":
+			return func(g graph.MatrixGraph, vertexCount, noEdgeValue int, seed int64) error {
+				return graph.GenerateUniformPointsGraph(g, vertexCount, noEdgeValue, squareSize, seed)
+			}, nil
+		case "3":
+			clusterCount, err := readInt("Podaj liczbę skupisk: ")
+			if err != nil || clusterCount <= 0 {
+				return nil, fmt.Errorf("nieprawidłowa liczba skupisk")
+			}
+			spread, err := readFloat("Podaj rozrzut skupiska jako ułamek boku kwadratu (np. 0.05): ")
+			if err != nil || spread < 0 {
+				return nil, fmt.Errorf("nieprawidłowy rozrzut skupiska")
+			}
+			return func(g graph.MatrixGraph, vertexCount, noEdgeValue int, seed int64) error {
+				return graph.GenerateClusteredPointsGraph(g, vertexCount, noEdgeValue, squareSize, clusterCount, spread, seed)
+			}, nil
+		case "4":
+			jitter, err := readFloat("Podaj zaburzenie jako ułamek rozstawu siatki (np. 0.2): ")
+			if err != nil || jitter < 0 {
+				return nil, fmt.Errorf("nieprawidłowe zaburzenie")
+			}
+			return func(g graph.MatrixGraph, vertexCount, noEdgeValue int, seed int64) error {
+				return graph.GenerateGridJitterGraph(g, vertexCount, noEdgeValue, squareSize, jitter, seed)
+			}, nil
+		default:
+			noise, err := readFloat("Podaj siłę zaburzenia odległości (np. 0.3): ")
+			if err != nil || noise < 0 {
+				return nil, fmt.Errorf("nieprawidłowa siła zaburzenia")
+			}
+			return func(g graph.MatrixGraph, vertexCount, noEdgeValue int, seed int64) error {
+				return graph.GenerateRoadLikeGraph(g, vertexCount, noEdgeValue, squareSize, noise, seed)
+			}, nil
+		}
+	case "6":
+		return func(g graph.MatrixGraph, vertexCount, noEdgeValue int, seed int64) error {
+			return graph.GenerateAmatGraph(g, vertexCount, noEdgeValue, 1000000, seed)
+		}, nil
+	case "7":
+		return func(g graph.MatrixGraph, vertexCount, noEdgeValue int, seed int64) error {
+			return graph.GenerateTmatGraph(g, vertexCount, noEdgeValue, 1000000, seed)
+		}, nil
+	case "8":
+		return func(g graph.MatrixGraph, vertexCount, noEdgeValue int, seed int64) error {
+			return graph.GenerateRtiltGraph(g, vertexCount, noEdgeValue, 1000000, 2, 0, seed)
+		}, nil
+	case "9":
+		return func(g graph.MatrixGraph, vertexCount, noEdgeValue int, seed int64) error {
+			return graph.GenerateStiltGraph(g, vertexCount, noEdgeValue, 1000000, 2, 0, seed)
+		}, nil
+	case "10":
+		return func(g graph.MatrixGraph, vertexCount, noEdgeValue int, seed int64) error {
+			return graph.GenerateCraneGraph(g, vertexCount, noEdgeValue, 1000000, 100000, seed)
+		}, nil
+	case "11":
+		return func(g graph.MatrixGraph, vertexCount, noEdgeValue int, seed int64) error {
+			return graph.GenerateDiskGraph(g, vertexCount, noEdgeValue, 1000, 1000, 1, seed)
+		}, nil
+	case "12":
+		return func(g graph.MatrixGraph, vertexCount, noEdgeValue int, seed int64) error {
+			return graph.GenerateCoinGraph(g, vertexCount, noEdgeValue, 100, 10, seed)
+		}, nil
+	case "13":
+		return func(g graph.MatrixGraph, vertexCount, noEdgeValue int, seed int64) error {
+			return graph.GenerateShopGraph(g, vertexCount, noEdgeValue, 50, 1000, seed)
+		}, nil
+	case "14":
+		return func(g graph.MatrixGraph, vertexCount, noEdgeValue int, seed int64) error {
+			return graph.GenerateSuperGraph(g, vertexCount, noEdgeValue, 20, 5, seed)
+		}, nil
+	case "15":
+		return func(g graph.MatrixGraph, vertexCount, noEdgeValue int, seed int64) error {
+			return graph.GenerateRectGraph(g, vertexCount, noEdgeValue, 1000000, seed)
+		}, nil
+	}
+	return nil, fmt.Errorf("nieznana klasa instancji: %s", choice)
+}
+
+// readCandidateLists pyta o metodę i długość list kandydatów ograniczających sąsiedztwo SA i TS
+func readCandidateLists(reader *bufio.Reader) (string, int) {
+	fmt.Println("Listy kandydatów (ograniczenie sąsiedztwa):")
+	fmt.Println("1. Brak - pełne sąsiedztwo")
+	fmt.Println("2. k najbliższych sąsiadów")
+	fmt.Println("3. k łuków o najmniejszej alfa-bliskości (1-arborescencja)")
+	fmt.Print("Wybierz opcję: ")
+	choice, _ := reader.ReadString('\n')
+	choice = strings.TrimSpace(choice)
+
+	var method string
+	switch choice {
+	case "2":
+		method = graph.CandidatesNearest
+	case "3":
+		method = graph.CandidatesAlpha
+	default:
+		return graph.CandidatesNone, 0
+	}
+	k, err := readInt("Podaj długość list kandydatów (np. 5): ")
+	if err != nil {
+		fmt.Println("Błąd odczytu długości list, użyto pełnego sąsiedztwa:", err)
+		return graph.CandidatesNone, 0
+	}
+	return method, k
+}
+
 // Helper functions to read input
 func readFloat(prompt string) (float64, error) {
 	reader := bufio.NewReader(os.Stdin)
@@ -2,7 +2,6 @@ package bf
 
 import (
 	"log"
-	"math"
 	"projekt2/graph"
 )
 
@@ -30,11 +29,21 @@ func (b *BFATSPSolver) SetStartVertex(startVertex int) {
 }
 
 func (b *BFATSPSolver) Solve() ([]int, int) {
+	return bruteForce[int](b)
+}
+
+// SolveFloat działa jak Solve, ale liczy koszty w float64 (dokładne wagi grafów graph.FloatGraph).
+// Nowa najlepsza trasa musi być tańsza o więcej niż tolerancję graph.FloatTolerance.
+func (b *BFATSPSolver) SolveFloat() ([]int, float64) {
+	return bruteForce[float64](b)
+}
+
+func bruteForce[W graph.Weight](b *BFATSPSolver) ([]int, W) {
 	log.Println("Rozpoczęcie Brute-Force dla wierzchołka początkowego:", b.startVertex, "z liczbą wierzchołków:", b.graph.GetVertexCount())
 
 	vertexCount := b.graph.GetVertexCount()
 
-	minPathCost := math.MaxInt             // Inicjalizacja minimalnego kosztu.
+	minPathCost := graph.MaxWeight[W]()    // Inicjalizacja minimalnego kosztu.
 	currentPath := make([]int, 0)          // Aktualna ścieżka.
 	visited := make([]bool, vertexCount)   // Tablica odwiedzonych wierzchołków.
 	bestPath := make([]int, vertexCount+1) // Najlepsza znaleziona ścieżka (z powrotem do startu).
@@ -44,23 +53,23 @@ func (b *BFATSPSolver) Solve() ([]int, int) {
 	currentPath = append(currentPath, b.startVertex)
 
 	// Rozpoczynamy rekurencyjne przeszukiwanie wszystkich możliwych ścieżek.
-	recursiveBruteForce(b.graph, b.startVertex, visited, 0, &minPathCost, currentPath, bestPath)
+	weights, adjacent := graph.WeightMatrix[W](b.graph)
+	recursiveBruteForce(weights, adjacent, b.startVertex, visited, 0, &minPathCost, currentPath, bestPath)
 
 	return bestPath, minPathCost
 }
 
 // Rekurencyjna funkcja przeszukująca wszystkie możliwe ścieżki.
-func recursiveBruteForce(g graph.Graph, currentVertex int, visited []bool, currentCost int, minPathCost *int, currentPath, bestPath []int) {
-	vertexCount := g.GetVertexCount()
+func recursiveBruteForce[W graph.Weight](weights [][]W, adjacent [][]bool, currentVertex int, visited []bool, currentCost W, minPathCost *W, currentPath, bestPath []int) {
+	vertexCount := len(weights)
 
 	// Jeśli odwiedziliśmy wszystkie wierzchołki, sprawdzamy powrót do wierzchołka startowego.
 	if len(currentPath) == vertexCount {
-		// Pobieramy krawędź z ostatniego wierzchołka do wierzchołka startowego.
-		edge := g.GetEdge(currentVertex, currentPath[0])
-		if edge.Weight != g.GetNoEdgeValue() {
-			totalCost := currentCost + edge.Weight
+		// Sprawdzamy krawędź z ostatniego wierzchołka do wierzchołka startowego.
+		if adjacent[currentVertex][currentPath[0]] {
+			totalCost := currentCost + weights[currentVertex][currentPath[0]]
 			// Sprawdzamy, czy całkowity koszt jest mniejszy od dotychczasowego minimalnego kosztu.
-			if totalCost < *minPathCost {
+			if graph.LessWeight(totalCost, *minPathCost) {
 				*minPathCost = totalCost
 				// Tworzymy tymczasową ścieżkę dodając powrót do wierzchołka startowego.
 				tempPath := append(currentPath, currentPath[0])
@@ -75,15 +84,14 @@ func recursiveBruteForce(g graph.Graph, currentVertex int, visited []bool, curre
 	for nextVertex := 0; nextVertex < vertexCount; nextVertex++ {
 		if !visited[nextVertex] {
 			// Sprawdzamy, czy istnieje krawędź z bieżącego wierzchołka do nextVertex.
-			edge := g.GetEdge(currentVertex, nextVertex)
-			if edge.Weight != g.GetNoEdgeValue() {
+			if adjacent[currentVertex][nextVertex] {
 				// Oznaczamy nextVertex jako odwiedzony i dodajemy go do aktualnej ścieżki.
 				visited[nextVertex] = true
 				currentPath = append(currentPath, nextVertex)
-				newCost := currentCost + edge.Weight
+				newCost := currentCost + weights[currentVertex][nextVertex]
 
 				// Rekurencyjne wywołanie dla nextVertex.
-				recursiveBruteForce(g, nextVertex, visited, newCost, minPathCost, currentPath, bestPath)
+				recursiveBruteForce(weights, adjacent, nextVertex, visited, newCost, minPathCost, currentPath, bestPath)
 
 				// Cofamy zmiany (backtracking): usuwamy nextVertex z aktualnej ścieżki i oznaczamy go jako nieodwiedzonego.
 				visited[nextVertex] = false
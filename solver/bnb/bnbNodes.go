@@ -1,29 +1,33 @@
 package bnb
 
-import "container/heap"
+import (
+	"container/heap"
+	"projekt2/graph"
+)
 
-type BNBNode struct {
+// BNBNode to węzeł drzewa przeszukiwania z dolnym ograniczeniem kosztu typu W (int lub float64)
+type BNBNode[W graph.Weight] struct {
 	vertex     int
-	lowerBound int
+	lowerBound W
 }
 
-type MinBNBNodeHeap []BNBNode
+type MinBNBNodeHeap[W graph.Weight] []BNBNode[W]
 
-func (h MinBNBNodeHeap) Len() int { return len(h) }
+func (h MinBNBNodeHeap[W]) Len() int { return len(h) }
 
-func (h MinBNBNodeHeap) Less(i, j int) bool {
+func (h MinBNBNodeHeap[W]) Less(i, j int) bool {
 	return h[i].lowerBound < h[j].lowerBound
 }
 
-func (h MinBNBNodeHeap) Swap(i, j int) {
+func (h MinBNBNodeHeap[W]) Swap(i, j int) {
 	h[i], h[j] = h[j], h[i]
 }
 
-func (h *MinBNBNodeHeap) Push(x interface{}) {
-	*h = append(*h, x.(BNBNode))
+func (h *MinBNBNodeHeap[W]) Push(x interface{}) {
+	*h = append(*h, x.(BNBNode[W]))
 }
 
-func (h *MinBNBNodeHeap) Pop() interface{} {
+func (h *MinBNBNodeHeap[W]) Pop() interface{} {
 	old := *h
 	n := len(old)
 	x := old[n-1]
@@ -31,15 +35,15 @@ func (h *MinBNBNodeHeap) Pop() interface{} {
 	return x
 }
 
-func NewBNBNodeHeapByInit(nodesArray []BNBNode) *MinBNBNodeHeap {
-	minBNBNodeHeap := &MinBNBNodeHeap{}
+func NewBNBNodeHeapByInit[W graph.Weight](nodesArray []BNBNode[W]) *MinBNBNodeHeap[W] {
+	minBNBNodeHeap := &MinBNBNodeHeap[W]{}
 	*minBNBNodeHeap = nodesArray
 	heap.Init(minBNBNodeHeap)
 	return minBNBNodeHeap
 }
 
-func NewBNBNodeHeapByPush(nodesArray []BNBNode) *MinBNBNodeHeap {
-	minBNBNodeHeap := &MinBNBNodeHeap{}
+func NewBNBNodeHeapByPush[W graph.Weight](nodesArray []BNBNode[W]) *MinBNBNodeHeap[W] {
+	minBNBNodeHeap := &MinBNBNodeHeap[W]{}
 	heap.Init(minBNBNodeHeap)
 	for _, node := range nodesArray {
 		heap.Push(minBNBNodeHeap, node)
@@ -2,13 +2,15 @@ package bnb
 
 import (
 	"container/heap"
+	"log"
 	"math"
 	"projekt2/graph"
 )
 
 type BNBATSPSolver struct {
-	graph       graph.Graph
-	startVertex int
+	graph           graph.Graph
+	startVertex     int
+	matrixReduction bool // Czy przeszukiwać graf po redukcji wierszy i kolumn (graph.ReduceGraph)
 }
 
 func NewBranchAndBoundATSPSolver(sv int) BNBATSPSolver {
@@ -29,86 +31,148 @@ func (b *BNBATSPSolver) SetStartVertex(startVertex int) {
 	b.startVertex = startVertex
 }
 
+// SetMatrixReduction włącza przeszukiwanie grafu po redukcji wierszy i kolumn. Optymalne trasy są te same,
+// a dolne ograniczenie liczone na zredukowanych wagach jest co najmniej tak dobre jak na oryginalnych.
+// Zwracany koszt jest zawsze liczony w wagach oryginalnego grafu.
+func (b *BNBATSPSolver) SetMatrixReduction(enabled bool) {
+	b.matrixReduction = enabled
+}
+
+// GetMatrixReduction zwraca true, jeśli przeszukiwanie odbywa się na grafie po redukcji wierszy i kolumn
+func (b *BNBATSPSolver) GetMatrixReduction() bool {
+	return b.matrixReduction
+}
+
 func (b *BNBATSPSolver) Solve() ([]int, int) {
+	return branchAndBound[int](b)
+}
+
+// SolveFloat działa jak Solve, ale liczy koszty w float64 (dokładne wagi grafów graph.FloatGraph).
+// Gałąź jest odcinana, gdy jej dolne ograniczenie nie jest mniejsze od najlepszego kosztu o więcej niż tolerancję.
+func (b *BNBATSPSolver) SolveFloat() ([]int, float64) {
+	return branchAndBound[float64](b)
+}
+
+func branchAndBound[W graph.Weight](b *BNBATSPSolver) ([]int, W) {
 	vertexCount := b.GetGraph().GetVertexCount()
 
+	source := b.graph
+	if b.matrixReduction {
+		if reduced, err := reducedGraph(b.graph); err != nil {
+			log.Println("Nie udało się zredukować macierzy, użyto oryginalnych wag:", err)
+		} else {
+			source = reduced
+		}
+	}
+
 	// Obliczamy początkowe dolne ograniczenie oraz minimalne koszty krawędzi wychodzących.
-	lowerBound, minEdgeLookup := calculateStartLowerBound(b.graph)
-	minPathCost := math.MaxInt
-	currentPath := make([]int, 0)                                       // Aktualna ścieżka.
-	visited := make([]bool, vertexCount)                                // Tablica odwiedzonych wierzchołków.
-	bestPath := make([]int, vertexCount+1)                              // Najlepsza znaleziona ścieżka.
-	startNode := BNBNode{vertex: b.startVertex, lowerBound: lowerBound} // Inicjalizacja początkowego węzła.
+	weights, adjacent := graph.WeightMatrix[W](source)
+	lowerBound, minEdgeLookup := calculateStartLowerBound(weights, adjacent)
+	minPathCost := graph.MaxWeight[W]()
+	currentPath := make([]int, 0)                                          // Aktualna ścieżka.
+	visited := make([]bool, vertexCount)                                   // Tablica odwiedzonych wierzchołków.
+	bestPath := make([]int, vertexCount+1)                                 // Najlepsza znaleziona ścieżka.
+	startNode := BNBNode[W]{vertex: b.startVertex, lowerBound: lowerBound} // Inicjalizacja początkowego węzła.
 
 	// Rozpoczynamy rekurencyjne przeszukiwanie drzewa rozwiązań.
-	recursiveBNB(b.graph, startNode, visited, &minPathCost, currentPath, bestPath, minEdgeLookup)
+	recursiveBNB(weights, adjacent, startNode, visited, &minPathCost, currentPath, bestPath, minEdgeLookup)
 
+	if minPathCost != graph.MaxWeight[W]() {
+		// Koszt liczony przyrostowo przez ograniczenia może się różnić od sumy wag o błąd zaokrągleń float64
+		minPathCost = graph.PathWeight[W](b.graph, bestPath)
+	}
 	return bestPath, minPathCost
 }
 
 // Funkcja oblicza początkowe dolne ograniczenie oraz tworzy tablicę minimalnych kosztów krawędzi wychodzących z każdego wierzchołka.
-func calculateStartLowerBound(g graph.Graph) (int, []int) {
-	lowerBound := 0
-	minEdgeLookup := make([]int, g.GetVertexCount())
-	for i := 0; i < g.GetVertexCount(); i++ {
+func calculateStartLowerBound[W graph.Weight](weights [][]W, adjacent [][]bool) (W, []W) {
+	var lowerBound W
+	minEdgeLookup := make([]W, len(weights))
+	for i := range weights {
+		// Wyznaczamy minimalny koszt krawędzi wychodzącej z wierzchołka i (jak GetMinEdgeFromWeight).
+		minEdge := graph.MaxWeight[W]()
+		for j, weight := range weights[i] {
+			if adjacent[i][j] && weight < minEdge {
+				minEdge = weight
+			}
+		}
 		// Dodajemy minimalny koszt krawędzi wychodzącej z wierzchołka i do dolnego ograniczenia.
-		lowerBound += g.GetMinEdgeFromWeight(i)
+		lowerBound += minEdge
 		// Zapamiętujemy minimalny koszt krawędzi wychodzącej z wierzchołka i.
-		minEdgeLookup[i] = g.GetMinEdgeFromWeight(i)
+		minEdgeLookup[i] = minEdge
 	}
 	return lowerBound, minEdgeLookup
 }
 
+// reducedGraph zwraca graf po redukcji wierszy i kolumn. Brakujące krawędzie są rozpoznawane
+// przez macierz sąsiedztwa, a math.MaxInt jako noEdgeValue nie koliduje z żadną zredukowaną wagą.
+func reducedGraph(g graph.Graph) (graph.Graph, error) {
+	representation := graph.RepresentationAdjMatrix
+	if graph.IsFloatGraph(g) {
+		representation = graph.RepresentationFloat
+	}
+	reduced, err := graph.NewMatrixGraph(representation, math.MaxInt)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := graph.ReduceGraph(g, reduced); err != nil {
+		return nil, err
+	}
+	return reduced, nil
+}
+
 // Funkcja oblicza dolne ograniczenie dla przejścia z bieżącego wierzchołka do następnego.
-func calculateLowerBound(g graph.Graph, currentBNBNode BNBNode, nextVertex int, minEdgeLookup []int) int {
+func calculateLowerBound[W graph.Weight](weights [][]W, currentBNBNode BNBNode[W], nextVertex int, minEdgeLookup []W) W {
 	// Aktualizujemy dolne ograniczenie, odejmując minimalny koszt krawędzi wychodzącej z bieżącego wierzchołka
 	// i dodając koszt rzeczywistej krawędzi do następnego wierzchołka.
-	return currentBNBNode.lowerBound - minEdgeLookup[currentBNBNode.vertex] + g.GetEdge(currentBNBNode.vertex, nextVertex).Weight
+	return currentBNBNode.lowerBound - minEdgeLookup[currentBNBNode.vertex] + weights[currentBNBNode.vertex][nextVertex]
 }
 
-// Rekurencyjna funkcja realizująca algorytm Branch and Bound.
-func recursiveBNB(g graph.Graph, currentBNBNode BNBNode, visited []bool, minPathCost *int, currentPath, bestPath, minEdgeLookup []int) {
+// Rekurencyjna funkcja realizująca algorytm Branch and Bound. Przechodzi tylko po istniejących krawędziach (adjacent).
+func recursiveBNB[W graph.Weight](weights [][]W, adjacent [][]bool, currentBNBNode BNBNode[W], visited []bool, minPathCost *W, currentPath, bestPath []int, minEdgeLookup []W) {
 	// Dodajemy bieżący wierzchołek do aktualnej ścieżki.
 	currentPath = append(currentPath, currentBNBNode.vertex)
 	// Oznaczamy bieżący wierzchołek jako odwiedzony.
 	visited[currentBNBNode.vertex] = true
 	// Tworzymy listę nieodwiedzonych węzłów do dalszego przeszukiwania.
-	notVisitedBNBNodes := make([]BNBNode, 0)
+	notVisitedBNBNodes := make([]BNBNode[W], 0)
 
 	// Przechodzimy przez wszystkie wierzchołki grafu.
-	for i := 0; i < g.GetVertexCount(); i++ {
-		if !visited[i] {
+	for i := 0; i < len(weights); i++ {
+		if !visited[i] && adjacent[currentBNBNode.vertex][i] {
 			// Obliczamy dolne ograniczenie dla przejścia do wierzchołka i.
-			newLowerBound := calculateLowerBound(g, currentBNBNode, i, minEdgeLookup)
+			newLowerBound := calculateLowerBound(weights, currentBNBNode, i, minEdgeLookup)
 			// Dodajemy nowy węzeł do listy nieodwiedzonych węzłów.
-			notVisitedBNBNodes = append(notVisitedBNBNodes, BNBNode{vertex: i, lowerBound: newLowerBound})
+			notVisitedBNBNodes = append(notVisitedBNBNodes, BNBNode[W]{vertex: i, lowerBound: newLowerBound})
 		}
 	}
 
 	// Tworzymy kopiec z nieodwiedzonych węzłów, aby zawsze wybierać ten z najniższym dolnym ograniczeniem.
 	notVisitedBNBNodesHeap := NewBNBNodeHeapByInit(notVisitedBNBNodes)
 
-	// Jeśli nie ma więcej węzłów do odwiedzenia (osiągnięto liść drzewa).
-	if notVisitedBNBNodesHeap.Len() == 0 {
-		// Obliczamy dolne ograniczenie dla powrotu do wierzchołka startowego.
-		returnToStartLowerBound := calculateLowerBound(g, currentBNBNode, currentPath[0], minEdgeLookup)
-		// Sprawdzamy, czy znaleziony koszt jest mniejszy od dotychczasowego minimalnego kosztu.
-		if returnToStartLowerBound < *minPathCost {
-			*minPathCost = returnToStartLowerBound
-			// Dodajemy powrót do wierzchołka startowego w aktualnej ścieżce.
-			currentPath = append(currentPath, currentPath[0])
-			// Kopiujemy aktualną ścieżkę jako najlepszą znalezioną.
-			copy(bestPath, currentPath)
+	// Jeśli odwiedzono wszystkie wierzchołki (osiągnięto liść drzewa), trasa zamyka się tylko istniejącą krawędzią powrotu.
+	if len(currentPath) == len(weights) {
+		if adjacent[currentBNBNode.vertex][currentPath[0]] {
+			// Obliczamy dolne ograniczenie dla powrotu do wierzchołka startowego.
+			returnToStartLowerBound := calculateLowerBound(weights, currentBNBNode, currentPath[0], minEdgeLookup)
+			// Sprawdzamy, czy znaleziony koszt jest mniejszy od dotychczasowego minimalnego kosztu.
+			if graph.LessWeight(returnToStartLowerBound, *minPathCost) {
+				*minPathCost = returnToStartLowerBound
+				// Dodajemy powrót do wierzchołka startowego w aktualnej ścieżce.
+				currentPath = append(currentPath, currentPath[0])
+				// Kopiujemy aktualną ścieżkę jako najlepszą znalezioną.
+				copy(bestPath, currentPath)
+			}
 		}
 	} else {
 		// Przechodzimy przez dostępne nieodwiedzone węzły.
 		for notVisitedBNBNodesHeap.Len() > 0 {
 			// Pobieramy węzeł z najniższym dolnym ograniczeniem.
-			nextBNBNode := heap.Pop(notVisitedBNBNodesHeap).(BNBNode)
+			nextBNBNode := heap.Pop(notVisitedBNBNodesHeap).(BNBNode[W])
 			// Jeśli dolne ograniczenie jest mniejsze od obecnego minimalnego kosztu, kontynuujemy przeszukiwanie.
-			if nextBNBNode.lowerBound < *minPathCost {
+			if graph.LessWeight(nextBNBNode.lowerBound, *minPathCost) {
 				// Rekurencyjne wywołanie dla następnego węzła.
-				recursiveBNB(g, nextBNBNode, visited, minPathCost, currentPath, bestPath, minEdgeLookup)
+				recursiveBNB(weights, adjacent, nextBNBNode, visited, minPathCost, currentPath, bestPath, minEdgeLookup)
 			}
 		}
 	}
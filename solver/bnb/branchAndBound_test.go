@@ -0,0 +1,91 @@
+package bnb
+
+import (
+	"math"
+	"math/rand"
+	"projekt2/graph"
+	"projekt2/solver/dp"
+	"testing"
+)
+
+// incompleteGraph buduje graf z cyklem 0 -> 1 -> ... -> 0 o wagach cycleWeight i losowymi
+// pozostałymi krawędziami (każda istnieje z prawdopodobieństwem density)
+func incompleteGraph(rng *rand.Rand, vertexCount, noEdgeValue, cycleWeight int, density float64) *graph.AdjMatrixGraph {
+	g := graph.NewAdjMatrixGraph(vertexCount, noEdgeValue)
+	for i := 0; i < vertexCount; i++ {
+		g.AddEdge(i, (i+1)%vertexCount, cycleWeight)
+	}
+	for i := 0; i < vertexCount; i++ {
+		for j := 0; j < vertexCount; j++ {
+			if i != j && j != (i+1)%vertexCount && rng.Float64() < density {
+				g.AddEdge(i, j, 1+rng.Intn(100))
+			}
+		}
+	}
+	return g
+}
+
+func TestBranchAndBoundIncompleteGraphMatchesDP(t *testing.T) {
+	tests := []struct {
+		name        string
+		vertexCount int
+		density     float64
+		cycleWeight int
+	}{
+		{"tylko cykl", 5, 0, 1},
+		{"rzadki 6", 6, 0.2, 50},
+		{"rzadki 7", 7, 0.3, 100},
+		{"średni 8", 8, 0.5, 100},
+		{"gęsty 8", 8, 0.9, 100},
+	}
+	for _, test := range tests {
+		for seed := int64(1); seed <= 5; seed++ {
+			g := incompleteGraph(rand.New(rand.NewSource(seed)), test.vertexCount, 100000000, test.cycleWeight, test.density)
+
+			dpSolver := dp.NewDynamicProgrammingATSPSolver(0)
+			dpSolver.SetGraph(g)
+			_, expected := dpSolver.Solve()
+
+			for _, reduction := range []bool{false, true} {
+				bnbSolver := NewBranchAndBoundATSPSolver(0)
+				bnbSolver.SetGraph(g)
+				bnbSolver.SetMatrixReduction(reduction)
+				path, cost := bnbSolver.Solve()
+				if cost != expected {
+					t.Errorf("%s, ziarno %d, redukcja %v: koszt %d, oczekiwano %d (trasa %v)", test.name, seed, reduction, cost, expected, path)
+				}
+				if _, err := graph.EvaluateTour(g, path); err != nil {
+					t.Errorf("%s, ziarno %d, redukcja %v: nieprawidłowa trasa %v: %v", test.name, seed, reduction, path, err)
+				}
+			}
+		}
+	}
+}
+
+// Krawędzie o wagach zaokrąglanych do noEdgeValue (0.4 przy noEdgeValue 0) istnieją i należą do optymalnej trasy
+func TestSolveFloatUsesEdgesRoundedToNoEdgeValue(t *testing.T) {
+	g := graph.NewFloatMatrixGraph(4, 0)
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			if i != j {
+				g.AddEdgeFloat(i, j, 10)
+			}
+		}
+		g.AddEdgeFloat(i, (i+1)%4, 0.4)
+	}
+	const expected = 1.6
+
+	dpSolver := dp.NewDynamicProgrammingATSPSolver(0)
+	dpSolver.SetGraph(g)
+	if path, cost := dpSolver.SolveFloat(); math.Abs(cost-expected) > 1e-9 {
+		t.Errorf("DP: koszt %v, oczekiwano %v (trasa %v)", cost, expected, path)
+	}
+	for _, reduction := range []bool{false, true} {
+		bnbSolver := NewBranchAndBoundATSPSolver(0)
+		bnbSolver.SetGraph(g)
+		bnbSolver.SetMatrixReduction(reduction)
+		if path, cost := bnbSolver.SolveFloat(); math.Abs(cost-expected) > 1e-9 {
+			t.Errorf("BnB, redukcja %v: koszt %v, oczekiwano %v (trasa %v)", reduction, cost, expected, path)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package cache
+
+import (
+	"log"
+	"projekt2/graph"
+	"projekt2/solver"
+)
+
+// CachedSolver opakowuje solver i zapisuje jego wyniki w pamięci podręcznej rozwiązań.
+// Dla solvera dokładnego (exact = true) zapamiętane optimum instancji jest zwracane bez uruchamiania solvera.
+// Dla heurystyki wynik tańszy od zapamiętanego optimum jest zgłaszany w logu jako ostrzeżenie.
+// Solve dla grafu graph.FloatGraph liczy koszty na wagach zaokrąglonych i omija pamięć podręczną.
+type CachedSolver struct {
+	solver      solver.FloatATSPSolver
+	cache       *SolutionCache
+	key         Key
+	exact       bool
+	startVertex int
+	lastHit     bool
+}
+
+// NewCachedSolver tworzy opakowanie solvera; name i parameters trafiają do klucza wpisów, a seed to ziarno solvera (0 - brak)
+func NewCachedSolver(s solver.FloatATSPSolver, c *SolutionCache, name, parameters string, seed int64, exact bool) *CachedSolver {
+	return &CachedSolver{
+		solver: s,
+		cache:  c,
+		key:    Key{SolverName: name, Parameters: parameters, Seed: seed},
+		exact:  exact,
+	}
+}
+
+func (c *CachedSolver) SetGraph(g graph.Graph) {
+	c.solver.SetGraph(g)
+}
+
+func (c *CachedSolver) GetGraph() graph.Graph {
+	return c.solver.GetGraph()
+}
+
+func (c *CachedSolver) SetStartVertex(startVertex int) {
+	c.startVertex = startVertex
+	c.solver.SetStartVertex(startVertex)
+}
+
+// LastHit zwraca true, jeśli ostatni wynik pochodził z pamięci podręcznej
+func (c *CachedSolver) LastHit() bool {
+	return c.lastHit
+}
+
+func (c *CachedSolver) Solve() ([]int, int) {
+	c.lastHit = false
+	if graph.IsFloatGraph(c.GetGraph()) {
+		return c.solver.Solve()
+	}
+	path, cost := c.solveCached(func() ([]int, float64) {
+		path, cost := c.solver.Solve()
+		return path, float64(cost)
+	})
+	return path, int(cost)
+}
+
+func (c *CachedSolver) SolveFloat() ([]int, float64) {
+	c.lastHit = false
+	return c.solveCached(c.solver.SolveFloat)
+}
+
+func (c *CachedSolver) solveCached(run func() ([]int, float64)) ([]int, float64) {
+	g := c.GetGraph()
+	key := c.key
+	key.InstanceHash = graph.HashGraph(g)
+
+	if optimum, ok := c.cache.Optimum(key.InstanceHash); ok && c.exact {
+		if path := rotateTour(optimum.Path, c.startVertex); path != nil && feasible(g, path) {
+			c.lastHit = true
+			return path, graph.PathWeightFloat(g, path)
+		}
+		log.Printf("Zapamiętane optimum (%s) nie jest trasą w grafie - zostanie pominięte", optimum.SolverName)
+	}
+
+	path, cost := run()
+	c.Record(key.InstanceHash, path, cost)
+	return path, cost
+}
+
+// Record zapisuje trasę znalezioną dla instancji o skrócie instanceHash (graph.HashGraph) poza Solve,
+// np. gdy pomiar czasu nie powinien obejmować haszowania i zapisu pliku. Trasy, które nie są cyklem Hamiltona
+// w grafie, są pomijane, aby błędny wynik nie trafił do pamięci podręcznej jako optimum.
+func (c *CachedSolver) Record(instanceHash string, path []int, cost float64) {
+	if path == nil {
+		return
+	}
+	if !feasible(c.GetGraph(), path) {
+		log.Printf("Trasa %s nie jest cyklem Hamiltona w grafie - nie zostanie zapamiętana", c.key.SolverName)
+		return
+	}
+	key := c.key
+	key.InstanceHash = instanceHash
+	if optimum, ok := c.cache.BelowOptimum(key.InstanceHash, cost); ok {
+		log.Printf("Ostrzeżenie: %s znalazł trasę o koszcie %s, mniejszym od zapamiętanego optimum %s (%s)",
+			key.SolverName, graph.FormatWeight(cost), graph.FormatWeight(optimum.Cost), optimum.SolverName)
+	}
+	if c.cache.Store(key, path, cost, c.exact) {
+		if err := c.cache.Save(); err != nil {
+			log.Println("Błąd zapisu pamięci podręcznej rozwiązań:", err)
+		}
+	}
+}
+
+// feasible sprawdza, czy ścieżka jest cyklem Hamiltona w grafie (graph.EvaluateTour)
+func feasible(g graph.Graph, path []int) bool {
+	_, err := graph.EvaluateTour(g, path)
+	return err == nil
+}
+
+// rotateTour zwraca zamkniętą trasę zaczynającą się w startVertex lub nil, jeśli trasa go nie zawiera
+func rotateTour(path []int, startVertex int) []int {
+	if len(path) < 2 {
+		return nil
+	}
+	tour := path[:len(path)-1]
+	for i, v := range tour {
+		if v == startVertex {
+			rotated := make([]int, 0, len(path))
+			rotated = append(rotated, tour[i:]...)
+			rotated = append(rotated, tour[:i]...)
+			return append(rotated, startVertex)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"projekt2/graph"
+	"projekt2/solver/dp"
+	"reflect"
+	"testing"
+)
+
+// fixedSolver zwraca zawsze tę samą trasę o koszcie 1, niezależnie od grafu
+type fixedSolver struct {
+	graph graph.Graph
+	path  []int
+	runs  int
+}
+
+func (f *fixedSolver) SetGraph(g graph.Graph)         { f.graph = g }
+func (f *fixedSolver) GetGraph() graph.Graph          { return f.graph }
+func (f *fixedSolver) SetStartVertex(startVertex int) {}
+func (f *fixedSolver) Solve() ([]int, int) {
+	f.runs++
+	return f.path, 1
+}
+func (f *fixedSolver) SolveFloat() ([]int, float64) {
+	path, cost := f.Solve()
+	return path, float64(cost)
+}
+
+// cycleGraph zwraca graf, w którym jedynym cyklem Hamiltona jest 0 -> 1 -> 2 -> 3 -> 0
+func cycleGraph() graph.Graph {
+	g := graph.NewAdjMatrixGraph(4, -1)
+	for i := 0; i < 4; i++ {
+		g.AddEdge(i, (i+1)%4, i+1)
+	}
+	g.AddEdge(0, 2, 1)
+	return g
+}
+
+func TestCachedSolverReturnsCachedOptimum(t *testing.T) {
+	g := cycleGraph()
+	c := newTestCache(t)
+	dpSolver := dp.NewDynamicProgrammingATSPSolver(0)
+	cached := NewCachedSolver(&dpSolver, c, "DP", "", 0, true)
+	cached.SetGraph(g)
+
+	path, cost := cached.Solve()
+	if cached.LastHit() || cost != 10 {
+		t.Fatalf("pierwsze uruchomienie: trasa %v, koszt %d, z pamięci %v", path, cost, cached.LastHit())
+	}
+
+	other := &fixedSolver{}
+	cachedOther := NewCachedSolver(other, c, "BF", "", 0, true)
+	cachedOther.SetGraph(g)
+	cachedOther.SetStartVertex(2)
+	path, cost = cachedOther.Solve()
+	if !cachedOther.LastHit() || other.runs != 0 {
+		t.Fatal("solver dokładny nie użył zapamiętanego optimum")
+	}
+	if want := []int{2, 3, 0, 1, 2}; !reflect.DeepEqual(path, want) || cost != 10 {
+		t.Errorf("trasa z pamięci %v (koszt %d), oczekiwano %v (koszt 10)", path, cost, want)
+	}
+}
+
+func TestCachedSolverRejectsInfeasibleTours(t *testing.T) {
+	g := cycleGraph()
+	hash := graph.HashGraph(g)
+	tests := []struct {
+		name string
+		path []int
+	}{
+		{"nieistniejąca krawędź", []int{0, 2, 1, 3, 0}},
+		{"powtórzony wierzchołek", []int{0, 1, 1, 3, 0}},
+		{"wierzchołek spoza grafu", []int{0, 1, 2, 7, 0}},
+	}
+	for _, test := range tests {
+		c := newTestCache(t)
+
+		// Błędna trasa solvera dokładnego nie trafia do pamięci podręcznej
+		bad := &fixedSolver{path: test.path}
+		cachedBad := NewCachedSolver(bad, c, "BnB", "", 0, true)
+		cachedBad.SetGraph(g)
+		cachedBad.Solve()
+		if _, ok := c.Optimum(hash); ok {
+			t.Errorf("%s: zapamiętano błędną trasę jako optimum", test.name)
+		}
+
+		// Błędny wpis w pliku jest pomijany, a solver uruchamiany
+		c.entries[Key{InstanceHash: hash, SolverName: "BnB"}] = Entry{Path: test.path, Cost: 1, Optimal: true,
+			Key: Key{InstanceHash: hash, SolverName: "BnB"}}
+		dpSolver := dp.NewDynamicProgrammingATSPSolver(0)
+		cached := NewCachedSolver(&dpSolver, c, "DP", "", 0, true)
+		cached.SetGraph(g)
+		if _, cost := cached.Solve(); cached.LastHit() || cost != 10 {
+			t.Errorf("%s: użyto błędnego wpisu (koszt %d, z pamięci %v)", test.name, cost, cached.LastHit())
+		}
+	}
+}
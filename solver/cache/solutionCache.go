@@ -0,0 +1,160 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"projekt2/graph"
+	"sort"
+	"time"
+)
+
+// DefaultCacheFile to domyślny plik pamięci podręcznej rozwiązań (w katalogu roboczym)
+const DefaultCacheFile = "solution_cache.json"
+
+// Key identyfikuje wynik w pamięci podręcznej: instancję (graph.HashGraph), solver, jego parametry i ziarno
+type Key struct {
+	InstanceHash string `json:"instance"`
+	SolverName   string `json:"solver"`
+	Parameters   string `json:"parameters,omitempty"` // Opis konfiguracji solvera, np. "tenure=10,neighborhood=insert"
+	Seed         int64  `json:"seed,omitempty"`       // Ziarno generatora liczb losowych solvera (0 - brak)
+}
+
+// Entry to najlepsza trasa znaleziona dla klucza
+type Entry struct {
+	Key
+	Path    []int     `json:"path"`    // Zamknięta ścieżka jak w wynikach solverów
+	Cost    float64   `json:"cost"`    // Koszt ścieżki (dla grafów całkowitoliczbowych liczba całkowita)
+	Optimal bool      `json:"optimal"` // Koszt jest optimum wyznaczonym przez solver dokładny
+	Updated time.Time `json:"updated"`
+}
+
+// SolutionCache przechowuje najlepsze trasy w pliku JSON.
+// Wpis jest zastępowany tylko lepszą trasą, więc pamięć podręczna zawsze zawiera najlepszy znany wynik.
+type SolutionCache struct {
+	filePath string
+	entries  map[Key]Entry
+}
+
+// LoadSolutionCache wczytuje pamięć podręczną z pliku; brak pliku oznacza pustą pamięć podręczną
+func LoadSolutionCache(filePath string) (*SolutionCache, error) {
+	c := &SolutionCache{filePath: filePath, entries: make(map[Key]Entry)}
+	data, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		c.entries[entry.Key] = entry
+	}
+	return c, nil
+}
+
+// Save zapisuje pamięć podręczną do pliku. Zapis odbywa się przez plik tymczasowy,
+// aby przerwany zapis nie uszkodził wcześniejszych wyników.
+func (c *SolutionCache) Save() error {
+	if c.filePath == "" {
+		return errors.New("pamięć podręczna rozwiązań nie ma pliku")
+	}
+	entries := make([]Entry, 0, len(c.entries))
+	for _, entry := range c.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(a, b int) bool {
+		ka, kb := entries[a].Key, entries[b].Key
+		if ka.InstanceHash != kb.InstanceHash {
+			return ka.InstanceHash < kb.InstanceHash
+		}
+		if ka.SolverName != kb.SolverName {
+			return ka.SolverName < kb.SolverName
+		}
+		if ka.Parameters != kb.Parameters {
+			return ka.Parameters < kb.Parameters
+		}
+		return ka.Seed < kb.Seed
+	})
+	// Jeden wpis w wierszu: plik pozostaje czytelny także dla długich tras
+	data := []byte("[\n")
+	for i, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		data = append(data, line...)
+		if i < len(entries)-1 {
+			data = append(data, ',')
+		}
+		data = append(data, '\n')
+	}
+	data = append(data, "]\n"...)
+
+	temp, err := os.CreateTemp(filepath.Dir(c.filePath), filepath.Base(c.filePath)+".tmp*")
+	if err != nil {
+		return err
+	}
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		os.Remove(temp.Name())
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		os.Remove(temp.Name())
+		return err
+	}
+	if err := os.Rename(temp.Name(), c.filePath); err != nil {
+		os.Remove(temp.Name())
+		return err
+	}
+	return nil
+}
+
+// Get zwraca wpis dla klucza
+func (c *SolutionCache) Get(key Key) (Entry, bool) {
+	entry, ok := c.entries[key]
+	return entry, ok
+}
+
+// Store zapamiętuje trasę, jeśli dla klucza nie ma jeszcze wpisu albo trasa jest od niego lepsza.
+// Trasa o równym koszcie oznaczona jako optymalna zastępuje wpis nieoznaczony. Zwraca true, jeśli wpis się zmienił.
+func (c *SolutionCache) Store(key Key, path []int, cost float64, optimal bool) bool {
+	if current, ok := c.entries[key]; ok {
+		better := graph.LessWeight(cost, current.Cost)
+		confirmsOptimum := optimal && !current.Optimal && !graph.LessWeight(current.Cost, cost)
+		if !better && !confirmsOptimum {
+			return false
+		}
+	}
+	stored := make([]int, len(path))
+	copy(stored, path)
+	c.entries[key] = Entry{Key: key, Path: stored, Cost: cost, Optimal: optimal, Updated: time.Now()}
+	return true
+}
+
+// Optimum zwraca najtańszy wpis oznaczony jako optymalny dla instancji (niezależnie od solvera i parametrów)
+func (c *SolutionCache) Optimum(instanceHash string) (Entry, bool) {
+	var best Entry
+	found := false
+	for _, entry := range c.entries {
+		if entry.InstanceHash == instanceHash && entry.Optimal && (!found || entry.Cost < best.Cost) {
+			best, found = entry, true
+		}
+	}
+	return best, found
+}
+
+// BelowOptimum zwraca zapamiętane optimum instancji, jeśli koszt jest od niego mniejszy (z tolerancją
+// graph.FloatTolerance). Taki wynik oznacza błąd solvera, niezgodność instancji lub błędny wpis w pamięci podręcznej.
+func (c *SolutionCache) BelowOptimum(instanceHash string, cost float64) (Entry, bool) {
+	optimum, ok := c.Optimum(instanceHash)
+	if !ok || !graph.LessWeight(cost, optimum.Cost) {
+		return Entry{}, false
+	}
+	return optimum, true
+}
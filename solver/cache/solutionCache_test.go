@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// newTestCache tworzy pustą pamięć podręczną w katalogu tymczasowym testu
+func newTestCache(t *testing.T) *SolutionCache {
+	c, err := LoadSolutionCache(filepath.Join(t.TempDir(), "cache.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestSolutionCacheStore(t *testing.T) {
+	key := Key{InstanceHash: "h", SolverName: "SA"}
+	tests := []struct {
+		name        string
+		cost        float64
+		optimal     bool
+		wantChanged bool
+		wantCost    float64
+		wantOptimal bool
+	}{
+		{"pierwszy wpis", 10, false, true, 10, false},
+		{"gorsza trasa", 11, false, false, 10, false},
+		{"równy koszt", 10, false, false, 10, false},
+		{"równy koszt jako optimum", 10, true, true, 10, true},
+		{"równy koszt bez optimum", 10, false, false, 10, true},
+		{"lepsza trasa", 9, false, true, 9, false},
+	}
+	c := newTestCache(t)
+	for _, test := range tests {
+		changed := c.Store(key, []int{0, 1, 0}, test.cost, test.optimal)
+		entry, _ := c.Get(key)
+		if changed != test.wantChanged || entry.Cost != test.wantCost || entry.Optimal != test.wantOptimal {
+			t.Errorf("%s: zmiana %v, koszt %v, optimum %v; oczekiwano %v, %v, %v",
+				test.name, changed, entry.Cost, entry.Optimal, test.wantChanged, test.wantCost, test.wantOptimal)
+		}
+	}
+}
+
+func TestSolutionCacheOptimumAndBelowOptimum(t *testing.T) {
+	c := newTestCache(t)
+	c.Store(Key{InstanceHash: "h", SolverName: "SA"}, []int{0, 1, 0}, 5, false)
+	c.Store(Key{InstanceHash: "h", SolverName: "DP"}, []int{0, 1, 0}, 7, true)
+	c.Store(Key{InstanceHash: "other", SolverName: "DP"}, []int{0, 1, 0}, 1, true)
+
+	optimum, ok := c.Optimum("h")
+	if !ok || optimum.Cost != 7 || optimum.SolverName != "DP" {
+		t.Fatalf("Optimum = %+v, %v; oczekiwano wpisu DP o koszcie 7", optimum, ok)
+	}
+	if _, ok := c.Optimum("missing"); ok {
+		t.Error("Optimum zwrócił wpis dla nieznanej instancji")
+	}
+
+	tests := []struct {
+		cost float64
+		want bool
+	}{
+		{6, true},
+		{7, false},
+		{7 - 1e-12, false}, // W granicach tolerancji graph.FloatTolerance
+		{8, false},
+	}
+	for _, test := range tests {
+		if _, below := c.BelowOptimum("h", test.cost); below != test.want {
+			t.Errorf("BelowOptimum(%v) = %v, oczekiwano %v", test.cost, below, test.want)
+		}
+	}
+}
+
+func TestSolutionCacheSaveAndLoad(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "cache.json")
+	empty, err := LoadSolutionCache(filePath)
+	if err != nil {
+		t.Fatal("brak pliku powinien dawać pustą pamięć podręczną:", err)
+	}
+	if _, ok := empty.Optimum("h"); ok {
+		t.Fatal("pusta pamięć podręczna zawiera wpis")
+	}
+
+	keys := []Key{
+		{InstanceHash: "h", SolverName: "DP"},
+		{InstanceHash: "h", SolverName: "TS", Parameters: "tabuTenure=10", Seed: 42},
+	}
+	empty.Store(keys[0], []int{0, 2, 1, 0}, 12, true)
+	empty.Store(keys[1], []int{1, 0, 2, 1}, 12.5, false)
+	if err := empty.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadSolutionCache(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range keys {
+		want, _ := empty.Get(key)
+		got, ok := loaded.Get(key)
+		if !ok || !reflect.DeepEqual(got.Path, want.Path) || got.Cost != want.Cost || got.Optimal != want.Optimal || !got.Updated.Equal(want.Updated) {
+			t.Errorf("wpis %+v po wczytaniu: %+v, oczekiwano %+v", key, got, want)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package dp
 
 import (
 	"log"
-	"math"
 	"projekt2/graph"
 )
 
@@ -30,6 +29,16 @@ func (d *DPATSPSolver) SetStartVertex(startVertex int) {
 }
 
 func (d *DPATSPSolver) Solve() ([]int, int) {
+	return dynamicProgramming[int](d)
+}
+
+// SolveFloat działa jak Solve, ale liczy koszty w float64 (dokładne wagi grafów graph.FloatGraph).
+// Koszt częściowego rozwiązania jest zastępowany tylko wtedy, gdy nowy jest mniejszy o więcej niż tolerancję.
+func (d *DPATSPSolver) SolveFloat() ([]int, float64) {
+	return dynamicProgramming[float64](d)
+}
+
+func dynamicProgramming[W graph.Weight](d *DPATSPSolver) ([]int, W) {
 	log.Println("Rozpoczęcie programowania dynamicznego dla wierzchołka początkowego:", d.startVertex, "z liczbą wierzchołków:", d.graph.GetVertexCount())
 
 	vertexCount := d.graph.GetVertexCount()
@@ -39,14 +48,16 @@ func (d *DPATSPSolver) Solve() ([]int, int) {
 	// gdzie każdy bit odpowiada jednemu wierzchołkowi
 
 	// Tworzenie mapy przechowującej koszty częściowych rozwiązań
-	memo := make([][]int, vertexCount)
+	maxWeight := graph.MaxWeight[W]()
+	weights, adjacent := graph.WeightMatrix[W](d.graph)
+	memo := make([][]W, vertexCount)
 	parent := make([][]int, vertexCount) // Dodatkowa tablica, aby zapamiętać, skąd przychodzimy
 	for i := range memo {
-		memo[i] = make([]int, 1<<vertexCount)   // np dla 4 wierzchołków 16 (2^4) bo od 0000 do 1111
+		memo[i] = make([]W, 1<<vertexCount)     // np dla 4 wierzchołków 16 (2^4) bo od 0000 do 1111
 		parent[i] = make([]int, 1<<vertexCount) // Inicjalizacja ścieżki (skąd przychodzimy)
 		for j := range memo[i] {
-			memo[i][j] = math.MaxInt // Inicjalizacja maksymalnym kosztem
-			parent[i][j] = -1        // Brak poprzedniego wierzchołka (na początku)
+			memo[i][j] = maxWeight // Inicjalizacja maksymalnym kosztem
+			parent[i][j] = -1      // Brak poprzedniego wierzchołka (na początku)
 		}
 	}
 
@@ -72,17 +83,16 @@ func (d *DPATSPSolver) Solve() ([]int, int) {
 					continue // Pomijamy, jeśli prevVertex nie jest w zbiorze
 				}
 
-				edge := d.graph.GetEdge(prevVertex, currentVertex)
-				if edge.Weight == d.graph.GetNoEdgeValue() {
+				if !adjacent[prevVertex][currentVertex] {
 					continue // Pomijamy, jeśli nie ma krawędzi
 				}
 
-				if memo[prevVertex][previousSubset] == math.MaxInt {
+				if memo[prevVertex][previousSubset] == maxWeight {
 					continue // Pomijamy, jeśli nie ma wartości dla tego podzbioru
 				}
 
-				newCost := memo[prevVertex][previousSubset] + edge.Weight
-				if newCost < memo[currentVertex][subset] {
+				newCost := memo[prevVertex][previousSubset] + weights[prevVertex][currentVertex]
+				if graph.LessWeight(newCost, memo[currentVertex][subset]) {
 					memo[currentVertex][subset] = newCost
 					parent[currentVertex][subset] = prevVertex // Zapamiętujemy poprzednika
 				}
@@ -91,23 +101,22 @@ func (d *DPATSPSolver) Solve() ([]int, int) {
 	}
 
 	// Znalezienie minimalnej ścieżki powrotnej do wierzchołka startowego
-	minCost := math.MaxInt
+	minCost := maxWeight
 	lastVertex := -1
 	for vertex := 0; vertex < vertexCount; vertex++ {
 		if vertex == d.startVertex {
 			continue
 		}
-		edge := d.graph.GetEdge(vertex, d.startVertex)
-		if edge.Weight == d.graph.GetNoEdgeValue() {
+		if !adjacent[vertex][d.startVertex] {
 			continue
 		}
 
-		if memo[vertex][allVisited] == math.MaxInt {
+		if memo[vertex][allVisited] == maxWeight {
 			continue // Pomijamy, jeśli nie ma rozwiązania dla tego podzbioru
 		}
 
-		totalCost := memo[vertex][allVisited] + edge.Weight
-		if totalCost < minCost {
+		totalCost := memo[vertex][allVisited] + weights[vertex][d.startVertex]
+		if graph.LessWeight(totalCost, minCost) {
 			minCost = totalCost
 			lastVertex = vertex
 		}
@@ -27,13 +27,37 @@ func (g *GRATSPSolver) SetStartVertex(startVertex int) {
 	g.startVertex = startVertex
 }
 
+// Solve uruchamia metodę najbliższego sąsiada z wierzchołka startowego i z pozostałych wierzchołków
+// i zwraca najtańszą trasę. Starty, z których nie da się zbudować trasy, oraz trasy z nieistniejącą krawędzią
+// lub kosztem przekraczającym zakres int (patrz graph.CalculatePathWeightChecked) są pomijane;
+// jeśli żaden start się nie powiedzie, zwracane jest nil, -1.
 func (g *GRATSPSolver) Solve() ([]int, int) {
-	bestPath := g.graph.GetHamiltonianPathGreedy(g.startVertex)
-	bestPathWeight := g.graph.CalculatePathWeight(bestPath)
-	for i := 1; i < g.graph.GetVertexCount(); i++ {
-		path := g.graph.GetHamiltonianPathGreedy(i)
-		pathWeight := g.graph.CalculatePathWeight(path)
-		if pathWeight < bestPathWeight {
+	return greedy[int](g)
+}
+
+// SolveFloat działa jak Solve, ale porównuje trasy według kosztu float64 (dokładne wagi grafów graph.FloatGraph)
+func (g *GRATSPSolver) SolveFloat() ([]int, float64) {
+	return greedy[float64](g)
+}
+
+func greedy[W graph.Weight](g *GRATSPSolver) ([]int, W) {
+	var bestPath []int
+	var bestPathWeight W = -1
+	for i := 0; i < g.graph.GetVertexCount(); i++ {
+		startVertex := i
+		if i == 0 {
+			startVertex = g.startVertex
+		}
+		path := g.graph.GetHamiltonianPathGreedy(startVertex)
+		if path == nil {
+			continue
+		}
+		pathWeight, err := graph.CalculatePathWeightChecked[W](g.graph, path)
+		if err != nil {
+			// Trasa zamyka się nieistniejącą krawędzią lub jej koszt przekracza zakres int
+			continue
+		}
+		if bestPath == nil || pathWeight < bestPathWeight {
 			bestPath = path
 			bestPathWeight = pathWeight
 		}
@@ -1,6 +1,7 @@
 package sa
 
 import (
+	"fmt"
 	"log"
 	"math"
 	"math/rand"
@@ -18,11 +19,16 @@ type SaATSPSolver struct {
 	iterations         int       // Liczba iteracji
 	timeout            int64     // Czas wykonania w nanosekundach
 	startTime          time.Time // Czas rozpoczęcia
+	candidateMethod    string    // Metoda list kandydatów (graph.CandidatesNone, CandidatesNearest, CandidatesAlpha)
+	candidateCount     int       // Długość list kandydatów
+	candidateCache     graph.CandidateCache
+	candidates         *graph.CandidateLists // Listy kandydatów bieżącego uruchomienia (nil - pełne sąsiedztwo)
 }
 
 // SetGraph ustawia graf dla solvera
 func (s *SaATSPSolver) SetGraph(g graph.Graph) {
 	s.graph = g
+	s.candidateCache.Clear()
 }
 
 // GetGraph zwraca przypisany graf
@@ -45,6 +51,25 @@ func (s *SaATSPSolver) GetTimeout() int64 {
 	return s.timeout
 }
 
+// SetCandidateLists sprawia, że drugi wierzchołek zamiany jest losowany z list kandydatów długości k
+// (graph.CandidatesNearest lub graph.CandidatesAlpha); graph.CandidatesNone przywraca losowanie z całej trasy
+func (s *SaATSPSolver) SetCandidateLists(method string, k int) error {
+	switch method {
+	case graph.CandidatesNone, "":
+		s.candidateMethod = graph.CandidatesNone
+		return nil
+	case graph.CandidatesNearest, graph.CandidatesAlpha:
+		if k <= 0 {
+			return fmt.Errorf("nieprawidłowa długość list kandydatów: %d", k)
+		}
+		s.candidateMethod = method
+		s.candidateCount = k
+		return nil
+	default:
+		return fmt.Errorf("nieprawidłowa metoda list kandydatów: %s", method)
+	}
+}
+
 // NewSimulatedAnnealingATSPSolver tworzy nowy solver
 func NewSimulatedAnnealingATSPSolver(initialTemperature float64, minimalTemperature float64, alpha float64, iterations int, timeout int64) SaATSPSolver {
 	return SaATSPSolver{
@@ -58,8 +83,12 @@ func NewSimulatedAnnealingATSPSolver(initialTemperature float64, minimalTemperat
 
 // calculateCost oblicza koszt danej ścieżki w grafie, wliczając powrót do startu
 // Zakłada, że path już kończy się na startVertex, więc nie dodaje go ponownie.
-func (s *SaATSPSolver) calculateCost(path []int) int {
-	return s.graph.CalculatePathWeight(path)
+// W grafie niepełnym (missingEdgePenalty > 0) każda brakująca krawędź kosztuje missingEdgePenalty.
+func calculateCost[W graph.Weight](g graph.Graph, path []int, missingEdgePenalty W) W {
+	if missingEdgePenalty > 0 {
+		return graph.PenalizedPathWeight(g, path, missingEdgePenalty)
+	}
+	return graph.PathWeight[W](g, path)
 }
 
 // getNeighbor generuje sąsiednie rozwiązanie poprzez zamianę pozycji dwóch wierzchołków (oprócz startVertex na początku i końca)
@@ -75,7 +104,19 @@ func (s *SaATSPSolver) getNeighbor(currentPath []int) []int {
 
 	// Losowanie dwóch pozycji do zamiany, pomijamy indeks 0 (startVertex) i ostatni indeks (również startVertex)
 	i := rand.Intn(vertexCount-2) + 1 // [1, vertexCount-2]
-	j := rand.Intn(vertexCount-2) + 1
+	j := i
+	if s.candidates != nil {
+		// Z listą kandydatów na pozycję i trafia kandydujący następnik wierzchołka z pozycji i-1
+		if successors := s.candidates.Outgoing[newPath[i-1]]; len(successors) > 0 {
+			successor := successors[rand.Intn(len(successors))]
+			for p := 1; p < vertexCount-1; p++ {
+				if newPath[p] == successor {
+					j = p
+					break
+				}
+			}
+		}
+	}
 	for j == i {
 		j = rand.Intn(vertexCount-2) + 1
 	}
@@ -87,12 +128,21 @@ func (s *SaATSPSolver) getNeighbor(currentPath []int) []int {
 }
 
 // acceptanceProbability oblicza prawdopodobieństwo przyjęcia gorszego rozwiązania
-func (s *SaATSPSolver) acceptanceProbability(delta int, temperature float64) float64 {
+func (s *SaATSPSolver) acceptanceProbability(delta float64, temperature float64) float64 {
 	return math.Exp(-float64(delta) / temperature)
 }
 
 // Solve rozwiązuje ATSP metodą Symulowanego Wyżarzania
 func (s *SaATSPSolver) Solve() ([]int, int) {
+	return simulatedAnnealing[int](s)
+}
+
+// SolveFloat działa jak Solve, ale liczy koszty w float64 (dokładne wagi grafów graph.FloatGraph)
+func (s *SaATSPSolver) SolveFloat() ([]int, float64) {
+	return simulatedAnnealing[float64](s)
+}
+
+func simulatedAnnealing[W graph.Weight](s *SaATSPSolver) ([]int, W) {
 	vertexCount := s.graph.GetVertexCount()
 	if vertexCount == 0 {
 		return nil, -1
@@ -103,7 +153,29 @@ func (s *SaATSPSolver) Solve() ([]int, int) {
 
 	// Wygeneruj początkowe rozwiązanie metodą zachłanną
 	currentSolution := s.graph.GetHamiltonianPathRandom(s.startVertex)
-	currentCost := s.calculateCost(currentSolution)
+	var missingEdgePenalty W
+	if !graph.IsCompleteGraph(s.graph) {
+		// Brakujące krawędzie są karane, aby trasy dopuszczalne były zawsze tańsze od niedopuszczalnych
+		missingEdgePenalty = graph.MissingEdgePenalty[W](s.graph)
+	}
+	if graph.IsSparseGraph(s.graph) {
+		// W grafie rzadkim losowa permutacja prawie zawsze zawiera brakujące krawędzie,
+		// dlatego startujemy od trasy zachłannej (jeśli istnieje)
+		if greedySolution := s.graph.GetHamiltonianPathGreedy(s.startVertex); greedySolution != nil {
+			currentSolution = greedySolution
+		}
+	}
+	currentCost := calculateCost(s.graph, currentSolution, missingEdgePenalty)
+
+	s.candidates = nil
+	if s.candidateMethod != "" && s.candidateMethod != graph.CandidatesNone {
+		lists, err := s.candidateCache.Get(s.graph, s.candidateMethod, s.candidateCount)
+		if err != nil {
+			log.Println("Nie udało się wyznaczyć list kandydatów, użyto pełnego sąsiedztwa:", err)
+		} else {
+			s.candidates = lists
+		}
+	}
 
 	// Ustawiamy najlepsze znane rozwiązanie
 	bestSolution := make([]int, len(currentSolution))
@@ -127,7 +199,7 @@ func (s *SaATSPSolver) Solve() ([]int, int) {
 		for iteration := 0; iteration < s.iterations; iteration++ {
 			// Generujemy sąsiada
 			newSolution := s.getNeighbor(currentSolution)
-			newCost := s.calculateCost(newSolution)
+			newCost := calculateCost(s.graph, newSolution, missingEdgePenalty)
 			delta := newCost - currentCost
 
 			if delta < 0 {
@@ -136,7 +208,7 @@ func (s *SaATSPSolver) Solve() ([]int, int) {
 				currentCost = newCost
 			} else {
 				// Gorsze rozwiązanie - sprawdzamy prawdopodobieństwo przyjęcia
-				ap := s.acceptanceProbability(delta, T)
+				ap := s.acceptanceProbability(float64(delta), T)
 				chance := rand.Float64()
 				if chance < ap {
 					currentSolution = newSolution
@@ -159,6 +231,13 @@ func (s *SaATSPSolver) Solve() ([]int, int) {
 	log.Println("Temperatura końcowa:", T)
 	log.Println("wartoś exp(-1/Tk) =", s.acceptanceProbability(1, T))
 
+	// Koszt zwracanej trasy jest liczony ponownie z kontrolą brakujących krawędzi i przepełnienia
+	checkedCost, err := graph.CalculatePathWeightChecked[W](s.graph, bestSolution)
+	if err != nil {
+		log.Println("Nie znaleziono dopuszczalnej trasy:", err)
+		return nil, -1
+	}
+
 	// bestSolution już kończy się na startVertex, więc nie musimy go doklejać
-	return bestSolution, bestCost
+	return bestSolution, checkedCost
 }
@@ -8,3 +8,10 @@ type ATSPSolver interface {
 	SetStartVertex(startVertex int)
 	Solve() ([]int, int)
 }
+
+// FloatATSPSolver jest implementowany przez solvery, które potrafią liczyć koszty w float64
+// (dla grafów graph.FloatGraph; dla pozostałych grafów wynik jest równy wynikowi Solve)
+type FloatATSPSolver interface {
+	ATSPSolver
+	SolveFloat() ([]int, float64)
+}
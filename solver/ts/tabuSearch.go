@@ -3,7 +3,6 @@ package ts
 import (
 	"fmt"
 	"log"
-	"math"
 	"projekt2/graph"
 	"time"
 )
@@ -21,10 +20,14 @@ type TsATSPSolver struct {
 	startTime          time.Time
 	tabuTenure         int    // Ile iteracji ruch pozostaje tabu
 	neighborhoodMethod string // Metoda sąsiedztwa: "swap" lub "insert"
+	candidateMethod    string // Metoda list kandydatów (graph.CandidatesNone, CandidatesNearest, CandidatesAlpha)
+	candidateCount     int    // Długość list kandydatów
+	candidateCache     graph.CandidateCache
 }
 
 func (t *TsATSPSolver) SetGraph(g graph.Graph) {
 	t.graph = g
+	t.candidateCache.Clear()
 }
 
 func (t *TsATSPSolver) GetGraph() graph.Graph {
@@ -55,6 +58,77 @@ func (t *TsATSPSolver) GetNeighborhoodMethod() string {
 	return t.neighborhoodMethod
 }
 
+// SetCandidateLists ogranicza przeszukiwane sąsiedztwo do ruchów tworzących łuki z list kandydatów
+// długości k (graph.CandidatesNearest lub graph.CandidatesAlpha); graph.CandidatesNone przywraca pełne sąsiedztwo
+func (t *TsATSPSolver) SetCandidateLists(method string, k int) error {
+	switch method {
+	case graph.CandidatesNone, "":
+		t.candidateMethod = graph.CandidatesNone
+		return nil
+	case graph.CandidatesNearest, graph.CandidatesAlpha:
+		if k <= 0 {
+			return fmt.Errorf("nieprawidłowa długość list kandydatów: %d", k)
+		}
+		t.candidateMethod = method
+		t.candidateCount = k
+		return nil
+	default:
+		return fmt.Errorf("nieprawidłowa metoda list kandydatów: %s", method)
+	}
+}
+
+// candidateMoves zwraca ruchy (i, j), po których wierzchołek z listy kandydatów staje obok swojego sąsiada.
+// Dla swap wstawiany jest następnik kandydujący za solution[i-1] lub poprzednik kandydujący przed solution[i+1];
+// dla insert wierzchołek solution[i] jest przenoszony za swojego kandydującego poprzednika lub przed następnika.
+func (t *TsATSPSolver) candidateMoves(solution []int, candidates *graph.CandidateLists) [][2]int {
+	vertexCount := len(solution)
+	position := make([]int, t.graph.GetVertexCount())
+	for p := 0; p < vertexCount-1; p++ {
+		position[solution[p]] = p
+	}
+	valid := func(i, j int) bool {
+		return j >= 1 && j <= vertexCount-2 && j != i
+	}
+
+	var moves [][2]int
+	for i := 1; i < vertexCount-1; i++ {
+		if t.neighborhoodMethod == NeighborhoodInsert {
+			element := solution[i]
+			for _, predecessor := range candidates.Incoming[element] {
+				j := position[predecessor]
+				if j < i {
+					j++
+				}
+				if valid(i, j) {
+					moves = append(moves, [2]int{i, j})
+				}
+			}
+			for _, successor := range candidates.Outgoing[element] {
+				j := position[successor]
+				if j > i {
+					j--
+				}
+				if valid(i, j) {
+					moves = append(moves, [2]int{i, j})
+				}
+			}
+			continue
+		}
+
+		for _, successor := range candidates.Outgoing[solution[i-1]] {
+			if j := position[successor]; valid(i, j) {
+				moves = append(moves, [2]int{min(i, j), max(i, j)})
+			}
+		}
+		for _, predecessor := range candidates.Incoming[solution[i+1]] {
+			if j := position[predecessor]; valid(i, j) {
+				moves = append(moves, [2]int{min(i, j), max(i, j)})
+			}
+		}
+	}
+	return moves
+}
+
 func NewTabuSearchATSPSolver(iterations int, timeout int64, tabuTenure int, neighborhoodMethod string) TsATSPSolver {
 	solver := TsATSPSolver{
 		iterations: iterations,
@@ -68,85 +142,109 @@ func NewTabuSearchATSPSolver(iterations int, timeout int64, tabuTenure int, neig
 	return solver
 }
 
-// calculateCost oblicza koszt danej ścieżki
-func (t *TsATSPSolver) calculateCost(path []int) int {
-	return t.graph.CalculatePathWeight(path)
+// calculateCost oblicza koszt danej ścieżki; w grafie niepełnym (missingEdgePenalty > 0)
+// każda brakująca krawędź kosztuje missingEdgePenalty
+func calculateCost[W graph.Weight](g graph.Graph, path []int, missingEdgePenalty W) W {
+	if missingEdgePenalty > 0 {
+		return graph.PenalizedPathWeight(g, path, missingEdgePenalty)
+	}
+	return graph.PathWeight[W](g, path)
 }
 
-// findBestNeighbor znajduje najlepszego sąsiada (zgodnie z metodą sąsiedztwa)
-func (t *TsATSPSolver) findBestNeighbor(currentSolution []int, tabuList [][]int, bestCost int) (bestPath []int, bestI, bestJ, bestNeighborCost int) {
+// findBestNeighbor znajduje najlepszego sąsiada (zgodnie z metodą sąsiedztwa).
+// Jeśli candidates != nil, rozważane są tylko ruchy wyznaczone przez listy kandydatów (candidateMoves).
+func findBestNeighbor[W graph.Weight](t *TsATSPSolver, currentSolution []int, tabuList [][]int, bestCost, missingEdgePenalty W, candidates *graph.CandidateLists) (bestPath []int, bestI, bestJ int, bestNeighborCost W) {
 	vertexCount := len(currentSolution)
-	bestNeighborCost = math.MaxInt32
+	bestNeighborCost = graph.MaxWeight[W]()
 
 	switch t.neighborhoodMethod {
 	case NeighborhoodSwap:
 		// Swap: zamiana par wierzchołków
-		for i := 1; i < vertexCount-1; i++ {
-			for j := i + 1; j < vertexCount-1; j++ {
-				// Zamiana
-				currentSolution[i], currentSolution[j] = currentSolution[j], currentSolution[i]
-
-				cost := t.calculateCost(currentSolution)
-				isTabu := (tabuList[i][j] > 0 || tabuList[j][i] > 0)
-				// Warunek aspiracji lub nie-tabu
-				if cost < bestNeighborCost && (!isTabu || cost < bestCost) {
-					bestNeighborCost = cost
-					// Kopiujemy ścieżkę tylko kiedy jest to potrzebne (najlepszy dotąd)
-					if bestPath == nil {
-						bestPath = make([]int, vertexCount)
-					}
-					copy(bestPath, currentSolution)
-					bestI, bestJ = i, j
+		evaluateSwap := func(i, j int) {
+			// Zamiana
+			currentSolution[i], currentSolution[j] = currentSolution[j], currentSolution[i]
+
+			cost := calculateCost(t.graph, currentSolution, missingEdgePenalty)
+			isTabu := (tabuList[i][j] > 0 || tabuList[j][i] > 0)
+			// Warunek aspiracji lub nie-tabu
+			if cost < bestNeighborCost && (!isTabu || cost < bestCost) {
+				bestNeighborCost = cost
+				// Kopiujemy ścieżkę tylko kiedy jest to potrzebne (najlepszy dotąd)
+				if bestPath == nil {
+					bestPath = make([]int, vertexCount)
 				}
+				copy(bestPath, currentSolution)
+				bestI, bestJ = i, j
+			}
 
-				// Cofnięcie zamiany
-				currentSolution[i], currentSolution[j] = currentSolution[j], currentSolution[i]
+			// Cofnięcie zamiany
+			currentSolution[i], currentSolution[j] = currentSolution[j], currentSolution[i]
+		}
+
+		if candidates != nil {
+			for _, move := range t.candidateMoves(currentSolution, candidates) {
+				evaluateSwap(move[0], move[1])
+			}
+			break
+		}
+		for i := 1; i < vertexCount-1; i++ {
+			for j := i + 1; j < vertexCount-1; j++ {
+				evaluateSwap(i, j)
 			}
 		}
 
 	case NeighborhoodInsert:
 		// Insert: przeniesienie wierzchołka z pozycji i na pozycję j
-		for i := 1; i < vertexCount-1; i++ {
-			for j := 1; j < vertexCount-1; j++ {
-				if i == j {
-					continue
-				}
-				// Tymczasowo zmodyfikujemy currentSolution, aby uzyskać sąsiada
-				// Zapisz wartość elementu do przeniesienia
-				element := currentSolution[i]
-
-				if i < j {
-					// Przesuwamy wierzchołki w lewo
-					copy(currentSolution[i:], currentSolution[i+1:j+1])
-					currentSolution[j] = element
-				} else {
-					// i > j
-					// Przesuwamy wierzchołki w prawo
-					copy(currentSolution[j+1:i+1], currentSolution[j:i])
-					currentSolution[j] = element
-				}
+		evaluateInsert := func(i, j int) {
+			// Tymczasowo zmodyfikujemy currentSolution, aby uzyskać sąsiada
+			// Zapisz wartość elementu do przeniesienia
+			element := currentSolution[i]
+
+			if i < j {
+				// Przesuwamy wierzchołki w lewo
+				copy(currentSolution[i:], currentSolution[i+1:j+1])
+				currentSolution[j] = element
+			} else {
+				// i > j
+				// Przesuwamy wierzchołki w prawo
+				copy(currentSolution[j+1:i+1], currentSolution[j:i])
+				currentSolution[j] = element
+			}
 
-				cost := t.calculateCost(currentSolution)
-				isTabu := (tabuList[i][j] > 0 || tabuList[j][i] > 0)
-				if cost < bestNeighborCost && (!isTabu || cost < bestCost) {
-					bestNeighborCost = cost
-					if bestPath == nil {
-						bestPath = make([]int, vertexCount)
-					}
-					copy(bestPath, currentSolution)
-					bestI, bestJ = i, j
+			cost := calculateCost(t.graph, currentSolution, missingEdgePenalty)
+			isTabu := (tabuList[i][j] > 0 || tabuList[j][i] > 0)
+			if cost < bestNeighborCost && (!isTabu || cost < bestCost) {
+				bestNeighborCost = cost
+				if bestPath == nil {
+					bestPath = make([]int, vertexCount)
 				}
+				copy(bestPath, currentSolution)
+				bestI, bestJ = i, j
+			}
 
-				// Przywracamy oryginalną kolejność
-				if i < j {
-					// element był w i, przeniesiony do j
-					// cofamy zmianę
-					copy(currentSolution[i+1:j+1], currentSolution[i:j])
-					currentSolution[i] = element
-				} else {
-					// i > j
-					copy(currentSolution[j:i], currentSolution[j+1:i+1])
-					currentSolution[i] = element
+			// Przywracamy oryginalną kolejność
+			if i < j {
+				// element był w i, przeniesiony do j
+				// cofamy zmianę
+				copy(currentSolution[i+1:j+1], currentSolution[i:j])
+				currentSolution[i] = element
+			} else {
+				// i > j
+				copy(currentSolution[j:i], currentSolution[j+1:i+1])
+				currentSolution[i] = element
+			}
+		}
+
+		if candidates != nil {
+			for _, move := range t.candidateMoves(currentSolution, candidates) {
+				evaluateInsert(move[0], move[1])
+			}
+			break
+		}
+		for i := 1; i < vertexCount-1; i++ {
+			for j := 1; j < vertexCount-1; j++ {
+				if i != j {
+					evaluateInsert(i, j)
 				}
 			}
 		}
@@ -157,7 +255,7 @@ func (t *TsATSPSolver) findBestNeighbor(currentSolution []int, tabuList [][]int,
 			for j := i + 1; j < vertexCount-1; j++ {
 				currentSolution[i], currentSolution[j] = currentSolution[j], currentSolution[i]
 
-				cost := t.calculateCost(currentSolution)
+				cost := calculateCost(t.graph, currentSolution, missingEdgePenalty)
 				isTabu := (tabuList[i][j] > 0 || tabuList[j][i] > 0)
 				if cost < bestNeighborCost && (!isTabu || cost < bestCost) {
 					bestNeighborCost = cost
@@ -177,6 +275,15 @@ func (t *TsATSPSolver) findBestNeighbor(currentSolution []int, tabuList [][]int,
 }
 
 func (t *TsATSPSolver) Solve() ([]int, int) {
+	return tabuSearch[int](t)
+}
+
+// SolveFloat działa jak Solve, ale liczy koszty w float64 (dokładne wagi grafów graph.FloatGraph)
+func (t *TsATSPSolver) SolveFloat() ([]int, float64) {
+	return tabuSearch[float64](t)
+}
+
+func tabuSearch[W graph.Weight](t *TsATSPSolver) ([]int, W) {
 	vertexCount := t.graph.GetVertexCount()
 	if vertexCount == 0 {
 		return nil, -1
@@ -186,7 +293,28 @@ func (t *TsATSPSolver) Solve() ([]int, int) {
 
 	// Generujemy początkowe losowe rozwiązanie
 	currentSolution := t.graph.GetHamiltonianPathRandom(t.startVertex)
-	currentCost := t.calculateCost(currentSolution)
+	var missingEdgePenalty W
+	if !graph.IsCompleteGraph(t.graph) {
+		// Brakujące krawędzie są karane, aby trasy dopuszczalne były zawsze tańsze od niedopuszczalnych
+		missingEdgePenalty = graph.MissingEdgePenalty[W](t.graph)
+	}
+	if graph.IsSparseGraph(t.graph) {
+		// W grafie rzadkim startujemy od trasy zachłannej (jeśli istnieje)
+		if greedySolution := t.graph.GetHamiltonianPathGreedy(t.startVertex); greedySolution != nil {
+			currentSolution = greedySolution
+		}
+	}
+	currentCost := calculateCost(t.graph, currentSolution, missingEdgePenalty)
+
+	var candidates *graph.CandidateLists
+	if t.candidateMethod != "" && t.candidateMethod != graph.CandidatesNone {
+		lists, err := t.candidateCache.Get(t.graph, t.candidateMethod, t.candidateCount)
+		if err != nil {
+			log.Println("Nie udało się wyznaczyć list kandydatów, użyto pełnego sąsiedztwa:", err)
+		} else {
+			candidates = lists
+		}
+	}
 
 	bestSolution := make([]int, len(currentSolution))
 	copy(bestSolution, currentSolution)
@@ -208,7 +336,7 @@ func (t *TsATSPSolver) Solve() ([]int, int) {
 		}
 
 		// Znajdujemy najlepszego sąsiada
-		newSolution, bestI, bestJ, neighborCost := t.findBestNeighbor(currentSolution, tabuList, bestCost)
+		newSolution, bestI, bestJ, neighborCost := findBestNeighbor(t, currentSolution, tabuList, bestCost, missingEdgePenalty, candidates)
 
 		if newSolution == nil {
 			// Brak sąsiadów lub nie udało się poprawić, kończymy
@@ -240,5 +368,11 @@ func (t *TsATSPSolver) Solve() ([]int, int) {
 	}
 
 	log.Println("Zakończono Tabu Search. Najlepszy znaleziony koszt:", bestCost)
-	return bestSolution, bestCost
+	// Koszt zwracanej trasy jest liczony ponownie z kontrolą brakujących krawędzi i przepełnienia
+	checkedCost, err := graph.CalculatePathWeightChecked[W](t.graph, bestSolution)
+	if err != nil {
+		log.Println("Nie znaleziono dopuszczalnej trasy:", err)
+		return nil, -1
+	}
+	return bestSolution, checkedCost
 }
@@ -15,12 +15,15 @@ func RunSAAmountTests(sizes []int) {
 	saSolver := sa.NewSimulatedAnnealingATSPSolver(1000000, 1e-9, 0.995, 1000, timeoutInNs)
 	saSolver.SetStartVertex(0)
 	results := make([][]int64, 0)
+	seeds := make([][]int64, 0)
 out:
 	for _, vertexCount := range sizes {
 		tempResults := make([]int64, 0)
+		tempSeeds := make([]int64, 0)
 		for i := 0; i < 100; i++ {
 			g := graph.NewAdjMatrixGraph(vertexCount, noEdgeValue)
-			graph.GenerateRandomGraph(g, vertexCount, -1, 100)
+			seed := utils.AmountTestSeed(vertexCount, i)
+			graph.GenerateRandomGraphWithSeed(g, vertexCount, -1, 100, seed)
 			saSolver.SetGraph(g)
 			startTime := time.Now()
 			_, weight := saSolver.Solve()
@@ -31,9 +34,13 @@ out:
 				break out
 			}
 			tempResults = append(tempResults, elapsed.Nanoseconds())
+			tempSeeds = append(tempSeeds, seed)
 			runtime.GC()
 		}
 		results = append(results, tempResults)
+		seeds = append(seeds, tempSeeds)
 	}
-	utils.SaveTimesToCSVFile(results, "sa_amount_tests_"+utils.GetDateForFilename()+".csv")
+	date := utils.GetDateForFilename()
+	utils.SaveTimesToCSVFile(results, "sa_amount_tests_"+date+".csv")
+	utils.SaveSeedsToCSVFile(seeds, "sa_amount_tests_seeds_"+date+".csv")
 }
@@ -15,12 +15,15 @@ func RunTSAmountTests(sizes []int) {
 	tsSolver := ts.NewTabuSearchATSPSolver(1000, timeoutInNs, 10, "swap")
 	tsSolver.SetStartVertex(0)
 	results := make([][]int64, 0)
+	seeds := make([][]int64, 0)
 out:
 	for _, vertexCount := range sizes {
 		tempResults := make([]int64, 0)
+		tempSeeds := make([]int64, 0)
 		for i := 0; i < 100; i++ {
 			g := graph.NewAdjMatrixGraph(vertexCount, noEdgeValue)
-			graph.GenerateRandomGraph(g, vertexCount, -1, 100)
+			seed := utils.AmountTestSeed(vertexCount, i)
+			graph.GenerateRandomGraphWithSeed(g, vertexCount, -1, 100, seed)
 			tsSolver.SetGraph(g)
 			startTime := time.Now()
 			_, weight := tsSolver.Solve()
@@ -31,9 +34,13 @@ out:
 				break out
 			}
 			tempResults = append(tempResults, elapsed.Nanoseconds())
+			tempSeeds = append(tempSeeds, seed)
 			runtime.GC()
 		}
 		results = append(results, tempResults)
+		seeds = append(seeds, tempSeeds)
 	}
-	utils.SaveTimesToCSVFile(results, "ts_amount_tests_"+utils.GetDateForFilename()+".csv")
+	date := utils.GetDateForFilename()
+	utils.SaveTimesToCSVFile(results, "ts_amount_tests_"+date+".csv")
+	utils.SaveSeedsToCSVFile(seeds, "ts_amount_tests_seeds_"+date+".csv")
 }
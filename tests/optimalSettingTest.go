@@ -1,8 +1,10 @@
 package tests
 
 import (
+	"fmt"
 	"log"
 	"projekt2/graph"
+	"projekt2/solver/cache"
 	"projekt2/solver/sa"
 	"projekt2/solver/ts"
 	"projekt2/utils"
@@ -11,33 +13,49 @@ import (
 
 func RunOptimalTS() {
 	smallGraph, mediumGraph, largeGraph := LoadTestGraphs()
+	solutionCache := loadSolutionCache()
 	timeoutInNs := utils.MinutesToNanoSeconds(5)
-	runSingleGraphTS(smallGraph, timeoutInNs, "ts_optimal_small_")
-	runSingleGraphTS(mediumGraph, timeoutInNs, "ts_optimal_medium_")
-	runSingleGraphTS(largeGraph, timeoutInNs, "ts_optimal_large_")
+	runSingleGraphTS(smallGraph, solutionCache, timeoutInNs, "ts_optimal_small_")
+	runSingleGraphTS(mediumGraph, solutionCache, timeoutInNs, "ts_optimal_medium_")
+	runSingleGraphTS(largeGraph, solutionCache, timeoutInNs, "ts_optimal_large_")
 }
 
 func RunOptimalSA() {
 	smallGraph, mediumGraph, largeGraph := LoadTestGraphs()
+	solutionCache := loadSolutionCache()
 	timeoutInNs := utils.MinutesToNanoSeconds(5)
-	runSingleGraphSA(smallGraph, timeoutInNs, "sa_optimal_small_")
-	runSingleGraphSA(mediumGraph, timeoutInNs, "sa_optimal_medium_")
-	runSingleGraphSA(largeGraph, timeoutInNs, "sa_optimal_large_")
+	runSingleGraphSA(smallGraph, solutionCache, timeoutInNs, "sa_optimal_small_")
+	runSingleGraphSA(mediumGraph, solutionCache, timeoutInNs, "sa_optimal_medium_")
+	runSingleGraphSA(largeGraph, solutionCache, timeoutInNs, "sa_optimal_large_")
 }
 
-func runSingleGraphTS(g graph.Graph, timeoutInNs int64, fileOutName string) {
+// loadSolutionCache wczytuje pamięć podręczną rozwiązań, aby zapisywać najlepsze trasy i ostrzegać
+// o kosztach mniejszych od zapamiętanego optimum
+func loadSolutionCache() *cache.SolutionCache {
+	solutionCache, err := cache.LoadSolutionCache(cache.DefaultCacheFile)
+	if err != nil {
+		log.Fatal("Błąd wczytywania pamięci podręcznej rozwiązań: ", err)
+	}
+	return solutionCache
+}
+
+func runSingleGraphTS(g graph.Graph, solutionCache *cache.SolutionCache, timeoutInNs int64, fileOutName string) {
 	results := make([][]int64, 2)
 	for i := 0; i < 2; i++ {
 		results[i] = make([]int64, 10)
 	}
 
 	tsSolver := ts.NewTabuSearchATSPSolver(1000, timeoutInNs, 10, "insert")
-	tsSolver.SetGraph(g)
-	tsSolver.SetStartVertex(0)
+	cachedSolver := cache.NewCachedSolver(&tsSolver, solutionCache, "TS", fmt.Sprintf("iterations=1000,timeout=%d,tabuTenure=10,neighborhood=insert", timeoutInNs), 0, false)
+	cachedSolver.SetGraph(g)
+	cachedSolver.SetStartVertex(0)
+	// Haszowanie i zapis pamięci podręcznej odbywają się poza mierzonym czasem
+	instanceHash := graph.HashGraph(g)
 	for i := 0; i < 10; i++ {
 		start := time.Now()
-		_, weight := tsSolver.Solve()
+		path, weight := tsSolver.Solve()
 		elapsed := time.Since(start)
+		cachedSolver.Record(instanceHash, path, float64(weight))
 		log.Println(" Time: ", elapsed, " Weight: ", weight, " Graph size: ", g.GetVertexCount())
 		results[0][i] = elapsed.Nanoseconds()
 		results[1][i] = int64(weight)
@@ -46,19 +64,23 @@ func runSingleGraphTS(g graph.Graph, timeoutInNs int64, fileOutName string) {
 
 }
 
-func runSingleGraphSA(g graph.Graph, timeoutInNs int64, fileOutName string) {
+func runSingleGraphSA(g graph.Graph, solutionCache *cache.SolutionCache, timeoutInNs int64, fileOutName string) {
 	results := make([][]int64, 2)
 	for i := 0; i < 2; i++ {
 		results[i] = make([]int64, 10)
 	}
 
 	saSolver := sa.NewSimulatedAnnealingATSPSolver(1000000, 1e-9, 0.995, 5000, timeoutInNs)
-	saSolver.SetGraph(g)
-	saSolver.SetStartVertex(0)
+	cachedSolver := cache.NewCachedSolver(&saSolver, solutionCache, "SA", fmt.Sprintf("initialTemperature=1e+06,minimalTemperature=1e-09,alpha=0.995,iterations=5000,timeout=%d", timeoutInNs), 0, false)
+	cachedSolver.SetGraph(g)
+	cachedSolver.SetStartVertex(0)
+	// Haszowanie i zapis pamięci podręcznej odbywają się poza mierzonym czasem
+	instanceHash := graph.HashGraph(g)
 	for i := 0; i < 10; i++ {
 		start := time.Now()
-		_, weight := saSolver.Solve()
+		path, weight := saSolver.Solve()
 		elapsed := time.Since(start)
+		cachedSolver.Record(instanceHash, path, float64(weight))
 		log.Println(" Time: ", elapsed, " Weight: ", weight, " Graph size: ", g.GetVertexCount())
 		results[0][i] = elapsed.Nanoseconds()
 		results[1][i] = int64(weight)
@@ -25,6 +25,12 @@ func SaveTimesToCSVFile(timesMatrix [][]int64, fileName string) {
 
 }
 
+// SaveSeedsToCSVFile zapisuje ziarna wygenerowanych instancji w tym samym układzie co SaveTimesToCSVFile,
+// dzięki czemu każdy pomiar można powiązać z instancją i odtworzyć ją (graph.GenerateRandomGraphWithSeed)
+func SaveSeedsToCSVFile(seedsMatrix [][]int64, fileName string) {
+	SaveTimesToCSVFile(seedsMatrix, fileName)
+}
+
 func transposeTimesMatrix(timesMatrix [][]int64) [][]int64 {
 	transposedMatrix := make([][]int64, len(timesMatrix[0]))
 	for i := 0; i < len(timesMatrix[0]); i++ {
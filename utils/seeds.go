@@ -0,0 +1,10 @@
+package utils
+
+// AmountTestsBaseSeed to stałe ziarno bazowe testów ilościowych - dzięki niemu kolejne uruchomienia
+// generują dokładnie te same grafy
+const AmountTestsBaseSeed int64 = 20240601
+
+// AmountTestSeed zwraca ziarno i-tego grafu o danej liczbie wierzchołków w testach ilościowych
+func AmountTestSeed(vertexCount, i int) int64 {
+	return AmountTestsBaseSeed + int64(vertexCount)*1000 + int64(i)
+}